@@ -1,3 +1,3 @@
 module github.com/DenisKnez/pargoser
 
-go 1.16
+go 1.19
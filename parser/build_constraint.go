@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"go/build"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+//BuildContext the target platform and the build tags the files are selected for,
+//the same way the go command selects the files of a package
+type BuildContext struct {
+	// GOOS the target operating system, like linux, the current one is used when empty
+	GOOS string
+	// GOARCH the target architecture, like amd64, the current one is used when empty
+	GOARCH string
+	// Tags the additional build tags that are satisfied, like integration for //go:build integration
+	Tags []string
+	// CgoEnabled satisfies the cgo build tag and selects the files that import "C"
+	CgoEnabled bool
+}
+
+//knownOS the operating systems the file name suffixes are recognized for, from go/build
+var knownOS = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true, "illumos": true,
+	"ios": true, "js": true, "linux": true, "nacl": true, "netbsd": true, "openbsd": true, "plan9": true,
+	"solaris": true, "wasip1": true, "windows": true, "zos": true,
+}
+
+//knownArch the architectures the file name suffixes are recognized for, from go/build
+var knownArch = map[string]bool{
+	"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true, "arm64": true, "arm64be": true,
+	"loong64": true, "mips": true, "mipsle": true, "mips64": true, "mips64le": true, "mips64p32": true,
+	"mips64p32le": true, "ppc": true, "ppc64": true, "ppc64le": true, "riscv": true, "riscv64": true, "s390": true,
+	"s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+}
+
+//goBuildContext returns the go/build context for the target, the files are read from the file system
+func (c BuildContext) goBuildContext(fsys fs.FS) build.Context {
+	ctxt := build.Default
+	if c.GOOS != "" {
+		ctxt.GOOS = c.GOOS
+	}
+	if c.GOARCH != "" {
+		ctxt.GOARCH = c.GOARCH
+	}
+	ctxt.BuildTags = c.Tags
+	ctxt.CgoEnabled = c.CgoEnabled
+	ctxt.JoinPath = path.Join
+	ctxt.OpenFile = func(filePath string) (io.ReadCloser, error) {
+		return fsys.Open(filePath)
+	}
+	return ctxt
+}
+
+//matchFile checks if the file inside the directory is built for the target, by the file name suffix
+//and the build constraints of the file
+func (c BuildContext) matchFile(fsys fs.FS, directoryPath string, name string) (bool, error) {
+	ctxt := c.goBuildContext(fsys)
+	matched, err := ctxt.MatchFile(directoryPath, name)
+	if err != nil || !matched || c.CgoEnabled {
+		return matched, err
+	}
+
+	// go/build only leaves out the files that import "C" when it imports the whole package
+	isCgo, err := importsC(fsys, path.Join(directoryPath, name))
+	if err != nil {
+		return false, err
+	}
+	return !isCgo, nil
+}
+
+//importsC checks if the file imports the "C" pseudo package, only the imports of the file are parsed
+func importsC(fsys fs.FS, filePath string) (bool, error) {
+	src, err := fs.ReadFile(fsys, filePath)
+	if err != nil {
+		return false, err
+	}
+	astFile, err := parser.ParseFile(token.NewFileSet(), filePath, src, parser.ImportsOnly)
+	if err != nil {
+		// the syntax errors are reported when the file is parsed
+		return false, nil
+	}
+	for _, importSpec := range astFile.Imports {
+		if importSpec.Path.Value == `"C"` {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+//parseBuildConstraint returns the build constraint expression of the file, the //go:build line
+//or the legacy // +build lines joined with &&, empty if the file has no build constraints.
+//Only the comments above the package clause that are not the package doc are build constraints
+func parseBuildConstraint(file parserGoFile) string {
+	var plusBuildExpr constraint.Expr
+	for _, astCommentGroup := range file.AstFile.Comments {
+		if astCommentGroup.Pos() >= file.AstFile.Package {
+			break
+		}
+		for _, astComment := range astCommentGroup.List {
+			if constraint.IsGoBuild(astComment.Text) {
+				expr, err := constraint.Parse(astComment.Text)
+				if err != nil {
+					continue
+				}
+				// the //go:build line takes precedence over the legacy lines
+				return expr.String()
+			}
+			if !constraint.IsPlusBuild(astComment.Text) || astCommentGroup == file.AstFile.Doc {
+				continue
+			}
+			expr, err := constraint.Parse(astComment.Text)
+			if err != nil {
+				continue
+			}
+			if plusBuildExpr == nil {
+				plusBuildExpr = expr
+			} else {
+				plusBuildExpr = &constraint.AndExpr{X: plusBuildExpr, Y: expr}
+			}
+		}
+	}
+
+	if plusBuildExpr == nil {
+		return ""
+	}
+	return plusBuildExpr.String()
+}
+
+//parseFileNameTarget returns the operating system and the architecture from the suffixes of the file name,
+//linux and amd64 for file_linux_amd64.go, the _test suffix is ignored and a name without _ has no suffixes
+func parseFileNameTarget(name string) (goos string, goarch string) {
+	if dot := strings.Index(name, "."); dot != -1 {
+		name = name[:dot]
+	}
+	underscore := strings.Index(name, "_")
+	if underscore == -1 {
+		return "", ""
+	}
+
+	suffixes := strings.Split(name[underscore:], "_")
+	if n := len(suffixes); n > 0 && suffixes[n-1] == "test" {
+		suffixes = suffixes[:n-1]
+	}
+	n := len(suffixes)
+	if n >= 2 && knownOS[suffixes[n-2]] && knownArch[suffixes[n-1]] {
+		return suffixes[n-2], suffixes[n-1]
+	}
+	if n >= 1 && knownOS[suffixes[n-1]] {
+		return suffixes[n-1], ""
+	}
+	if n >= 1 && knownArch[suffixes[n-1]] {
+		return "", suffixes[n-1]
+	}
+	return "", ""
+}
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseFileNameTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantGOOS   string
+		wantGOARCH string
+	}{
+		{name: "file.go"},
+		{name: "file_linux.go", wantGOOS: "linux"},
+		{name: "file_amd64.go", wantGOARCH: "amd64"},
+		{name: "file_linux_amd64.go", wantGOOS: "linux", wantGOARCH: "amd64"},
+		{name: "file_windows_test.go", wantGOOS: "windows"},
+		{name: "file_darwin_arm64_test.go", wantGOOS: "darwin", wantGOARCH: "arm64"},
+		{name: "linux.go"},
+		{name: "file_unknown.go"},
+		{name: "file_amd64_linux.go", wantGOOS: "linux"},
+	}
+	for _, test := range tests {
+		goos, goarch := parseFileNameTarget(test.name)
+		if goos != test.wantGOOS || goarch != test.wantGOARCH {
+			t.Errorf("parseFileNameTarget(%q) = %q %q, want %q %q", test.name, goos, goarch, test.wantGOOS, test.wantGOARCH)
+		}
+	}
+}
+
+func TestParseBuildConstraint(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "none", src: "package p\n"},
+		{name: "go build", src: "//go:build linux && !cgo\n\npackage p\n", want: "linux && !cgo"},
+		{name: "legacy lines", src: "// +build linux darwin\n// +build amd64\n\npackage p\n", want: "(linux || darwin) && amd64"},
+		{name: "go build takes precedence", src: "//go:build linux\n// +build windows\n\npackage p\n", want: "linux"},
+		{name: "after the package clause", src: "package p\n\n//go:build linux\n"},
+		{name: "inside the package doc", src: "// Package p\n// +build linux\npackage p\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			par := mustParseSource(t, test.src)
+			packages, err := par.GetPackages()
+			if err != nil {
+				t.Fatalf("GetPackages() error = %v", err)
+			}
+			if got := packages[0].Files[0].BuildConstraint; got != test.want {
+				t.Errorf("BuildConstraint = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBuildContext(t *testing.T) {
+	fsys := fstest.MapFS{
+		"p/p.go":               {Data: []byte("package p\n")},
+		"p/p_linux.go":         {Data: []byte("package p\n")},
+		"p/p_windows.go":       {Data: []byte("package p\n")},
+		"p/p_linux_arm64.go":   {Data: []byte("package p\n")},
+		"p/integration.go":     {Data: []byte("//go:build integration\n\npackage p\n")},
+		"p/legacy.go":          {Data: []byte("// +build !windows\n\npackage p\n")},
+		"p/cgo.go":             {Data: []byte("package p\n\nimport \"C\"\n")},
+		"p/ignored.go":         {Data: []byte("//go:build ignore\n\npackage main\n")},
+		"p/p_windows_amd64.go": {Data: []byte("package p\n")},
+	}
+
+	tests := []struct {
+		name    string
+		context *BuildContext
+		want    []string
+	}{
+		{
+			name: "all files without a build context",
+			want: []string{"p/cgo.go", "p/ignored.go", "p/integration.go", "p/legacy.go", "p/p.go",
+				"p/p_linux.go", "p/p_linux_arm64.go", "p/p_windows.go", "p/p_windows_amd64.go"},
+		},
+		{
+			name:    "linux amd64",
+			context: &BuildContext{GOOS: "linux", GOARCH: "amd64"},
+			want:    []string{"p/legacy.go", "p/p.go", "p/p_linux.go"},
+		},
+		{
+			name:    "linux arm64 with tags and cgo",
+			context: &BuildContext{GOOS: "linux", GOARCH: "arm64", Tags: []string{"integration"}, CgoEnabled: true},
+			want:    []string{"p/cgo.go", "p/integration.go", "p/legacy.go", "p/p.go", "p/p_linux.go", "p/p_linux_arm64.go"},
+		},
+		{
+			name:    "windows amd64",
+			context: &BuildContext{GOOS: "windows", GOARCH: "amd64"},
+			want:    []string{"p/p.go", "p/p_windows.go", "p/p_windows_amd64.go"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsedFiles, _, err := NewParserFS(fsys, ".", ParserOptions{Recursive: true, BuildContext: test.context})
+			if err != nil {
+				t.Fatalf("NewParserFS() error = %v", err)
+			}
+			sort.Strings(parsedFiles)
+			if !reflect.DeepEqual(parsedFiles, test.want) {
+				t.Errorf("NewParserFS() parsed files = %v, want %v", parsedFiles, test.want)
+			}
+		})
+	}
+}
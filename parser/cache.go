@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"sync"
+)
+
+//methodSetKey identifies the method set of the value or the pointer of a type
+type methodSetKey struct {
+	key     typeKey
+	pointer bool
+}
+
+//parserCache the types and the method sets resolved by the parser, every type is converted once
+//and shared by all the lookups, it's safe to use from multiple goroutines
+type parserCache struct {
+	mutex      sync.Mutex
+	types      map[typeKey]*resolvedType
+	methodSets map[methodSetKey][]*Method
+}
+
+//packageCache returns the cache of the parser the package belongs to, nil if the package has no parser
+func packageCache(pkg *parserPackage) *parserCache {
+	if pkg == nil || pkg.Parser == nil {
+		return nil
+	}
+	return &pkg.Parser.cache
+}
+
+//resolvedType returns the cached type, the type can be nil if it's known that it can't be resolved
+func (c *parserCache) resolvedType(key typeKey) (resolved *resolvedType, ok bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	resolved, ok = c.types[key]
+	return resolved, ok
+}
+
+//setResolvedType caches the type, the first type cached for the key is kept
+func (c *parserCache) setResolvedType(key typeKey, resolved *resolvedType) *resolvedType {
+	if c == nil {
+		return resolved
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cached, ok := c.types[key]; ok {
+		return cached
+	}
+	if c.types == nil {
+		c.types = map[typeKey]*resolvedType{}
+	}
+	c.types[key] = resolved
+	return resolved
+}
+
+//methodSet returns a copy of the cached method set
+func (c *parserCache) methodSet(key methodSetKey) (methods []*Method, ok bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	methods, ok = c.methodSets[key]
+	return append([]*Method(nil), methods...), ok
+}
+
+//setMethodSet caches the method set
+func (c *parserCache) setMethodSet(key methodSetKey, methods []*Method) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.methodSets == nil {
+		c.methodSets = map[methodSetKey][]*Method{}
+	}
+	c.methodSets[key] = append([]*Method(nil), methods...)
+}
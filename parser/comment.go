@@ -0,0 +1,259 @@
+package parser
+
+import (
+	"go/ast"
+	"go/doc/comment"
+)
+
+//getPackageDoc returns the package doc comment, the doc.go file is preferred like go doc does,
+//otherwise the first file with the package doc is used, returns nil if the package is not documented
+func getPackageDoc(pkg *parserPackage) *CommentGroup {
+	var docFile *parserGoFile
+	for _, file := range pkg.GoFiles {
+		if file.AstFile.Doc == nil {
+			continue
+		}
+		if file.Name == "doc.go" {
+			docFile = file
+			break
+		}
+		if docFile == nil {
+			docFile = file
+		}
+	}
+
+	if docFile == nil {
+		return nil
+	}
+	return convertCommentGroup(*docFile, docFile.AstFile.Doc)
+}
+
+//parseHeaderComments returns the comments above the package clause, without the package doc
+func parseHeaderComments(file parserGoFile) (headerComments []*CommentGroup) {
+	for _, astCommentGroup := range file.AstFile.Comments {
+		if astCommentGroup.Pos() >= file.AstFile.Package {
+			break
+		}
+		if astCommentGroup == file.AstFile.Doc {
+			continue
+		}
+		headerComments = append(headerComments, convertCommentGroup(file, astCommentGroup))
+	}
+	return headerComments
+}
+
+//parseFloatingComments returns the comments below the package clause that are not the doc or the trailing
+//comment of any declaration, spec, field or method and are not inside a function body
+func parseFloatingComments(file parserGoFile) (floatingComments []*CommentGroup) {
+	for _, astCommentGroup := range parseFloatingCommentGroups(file) {
+		floatingComments = append(floatingComments, convertCommentGroup(file, astCommentGroup))
+	}
+	return floatingComments
+}
+
+//parseFloatingCommentGroups returns the ast comment groups of parseFloatingComments
+func parseFloatingCommentGroups(file parserGoFile) (astCommentGroups []*ast.CommentGroup) {
+	attached := map[*ast.CommentGroup]bool{}
+	bodies := []*ast.BlockStmt{}
+	ast.Inspect(file.AstFile, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.GenDecl:
+			attached[node.Doc] = true
+		case *ast.FuncDecl:
+			attached[node.Doc] = true
+			if node.Body != nil {
+				bodies = append(bodies, node.Body)
+			}
+		case *ast.FuncLit:
+			bodies = append(bodies, node.Body)
+		case *ast.TypeSpec:
+			attached[node.Doc], attached[node.Comment] = true, true
+		case *ast.ValueSpec:
+			attached[node.Doc], attached[node.Comment] = true, true
+		case *ast.ImportSpec:
+			attached[node.Doc], attached[node.Comment] = true, true
+		case *ast.Field:
+			attached[node.Doc], attached[node.Comment] = true, true
+		}
+		return true
+	})
+
+	for _, astCommentGroup := range file.AstFile.Comments {
+		if astCommentGroup.Pos() < file.AstFile.Package || attached[astCommentGroup] ||
+			isInsideBody(bodies, astCommentGroup) {
+			continue
+		}
+		astCommentGroups = append(astCommentGroups, astCommentGroup)
+	}
+	return astCommentGroups
+}
+
+//isInsideBody checks if the comment group is inside one of the function bodies
+func isInsideBody(bodies []*ast.BlockStmt, astCommentGroup *ast.CommentGroup) bool {
+	for _, body := range bodies {
+		if body.Lbrace < astCommentGroup.Pos() && astCommentGroup.End() <= body.Rbrace {
+			return true
+		}
+	}
+	return false
+}
+
+//Text returns the text of the comments without the comment markers, the same way ast.CommentGroup.Text does
+func (cg CommentGroup) Text() string {
+	astCommentGroup := &ast.CommentGroup{}
+	for _, comment := range cg.Comments {
+		astCommentGroup.List = append(astCommentGroup.List, &ast.Comment{Text: comment.Text})
+	}
+	return astCommentGroup.Text()
+}
+
+//Parse parses the comments following the go doc comment syntax into paragraphs, headings, lists and code blocks,
+//the doc links ([Name], [pkg.Name], [Recv.Method]) are resolved against the imports of the file and the parsed packages
+func (cg CommentGroup) Parse() *DocComment {
+	parser := comment.Parser{}
+	if cg.file != nil {
+		file := *cg.file
+		parser.LookupPackage = func(name string) (importPath string, ok bool) {
+			return lookupDocLinkPackage(file, name)
+		}
+		parser.LookupSym = func(recv, name string) bool {
+			return file.Package != nil && packageDeclaresSymbol(file.Package, recv, name)
+		}
+	}
+	doc := parser.Parse(cg.Text())
+
+	docComment := &DocComment{Blocks: convertDocBlocks(cg.file, doc.Content)}
+	for _, link := range doc.Links {
+		docComment.Links = append(docComment.Links, &DocLinkDef{Text: link.Text, URL: link.URL, Used: link.Used})
+	}
+	return docComment
+}
+
+//lookupDocLinkPackage returns the import path of the package name used in the doc link,
+//the imports of the file are checked first and then the names of the parsed packages
+func lookupDocLinkPackage(file parserGoFile, name string) (importPath string, ok bool) {
+	for _, importSpec := range file.AstFile.Imports {
+		if importName, importPath := importName(file, importSpec); importName == name {
+			return importPath, true
+		}
+	}
+
+	if file.Package == nil || file.Package.Parser == nil {
+		return "", false
+	}
+	for _, pkg := range file.Package.Parser.packages {
+		if pkg != file.Package && pkg.ImportPath != "" && getPackageName(*pkg.GoFiles[0]) == name {
+			return pkg.ImportPath, true
+		}
+	}
+	return "", false
+}
+
+//packageDeclaresSymbol checks if the const, func, type or var, or the method of the type if the receiver
+//is provided, is declared in the package
+func packageDeclaresSymbol(pkg *parserPackage, recv string, name string) bool {
+	if recv == "" {
+		if _, ok := pkg.TypeSpecs[name]; ok {
+			return true
+		}
+		if _, ok := pkg.Constants[name]; ok {
+			return true
+		}
+	}
+
+	for _, file := range pkg.GoFiles {
+		for _, funcDecl := range parseFuncDeclarations(*file) {
+			if funcDecl.Name.Name != name {
+				continue
+			}
+			if funcDecl.Recv == nil && recv == "" {
+				return true
+			}
+			if funcDecl.Recv != nil && len(funcDecl.Recv.List) != 0 &&
+				receiverBaseTypeName(funcDecl.Recv.List[0].Type) == recv {
+				return true
+			}
+		}
+		if recv != "" {
+			continue
+		}
+		for _, genDecl := range parseVariableDecls(parseGenDeclarations(*file)) {
+			for _, spec := range genDecl.Specs {
+				for _, ident := range spec.(*ast.ValueSpec).Names {
+					if ident.Name == name {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	// the methods of the interfaces
+	if typeSpec, ok := pkg.TypeSpecs[recv]; ok {
+		if interfaceType, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+			for _, method := range interfaceType.Methods.List {
+				if parseInterfaceMethodName(method) == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+//convertDocBlocks converts the go/doc/comment blocks into this libraries representation of the doc blocks
+func convertDocBlocks(file *parserGoFile, blocks []comment.Block) (docBlocks []*DocBlock) {
+	for _, block := range blocks {
+		switch block := block.(type) {
+		case *comment.Paragraph:
+			docBlocks = append(docBlocks, &DocBlock{Kind: ParagraphDocBlock, Text: convertDocText(file, block.Text)})
+		case *comment.Heading:
+			docBlocks = append(docBlocks, &DocBlock{Kind: HeadingDocBlock, Text: convertDocText(file, block.Text)})
+		case *comment.Code:
+			docBlocks = append(docBlocks, &DocBlock{Kind: CodeDocBlock, Code: block.Text})
+		case *comment.List:
+			docBlock := &DocBlock{Kind: ListDocBlock}
+			for _, item := range block.Items {
+				docBlock.Items = append(docBlock.Items, &DocListItem{
+					Number:  item.Number,
+					Content: convertDocBlocks(file, item.Content),
+				})
+			}
+			docBlocks = append(docBlocks, docBlock)
+		}
+	}
+	return docBlocks
+}
+
+//convertDocText converts the go/doc/comment text into this libraries representation of the doc text
+func convertDocText(file *parserGoFile, texts []comment.Text) (docTexts []*DocText) {
+	for _, text := range texts {
+		switch text := text.(type) {
+		case comment.Plain:
+			docTexts = append(docTexts, &DocText{Kind: PlainDocText, Text: string(text)})
+		case comment.Italic:
+			docTexts = append(docTexts, &DocText{Kind: ItalicDocText, Text: string(text)})
+		case *comment.Link:
+			docTexts = append(docTexts, &DocText{Kind: LinkDocText, URL: text.URL, Content: convertDocText(file, text.Text)})
+		case *comment.DocLink:
+			docText := &DocText{
+				Kind:       DocLinkDocText,
+				Content:    convertDocText(file, text.Text),
+				ImportPath: text.ImportPath,
+				Recv:       text.Recv,
+				Name:       text.Name,
+			}
+			if file != nil {
+				pkg := file.Package
+				if text.ImportPath == "" && pkg != nil {
+					docText.ImportPath = pkg.ImportPath
+				} else {
+					pkg = findPackageByImportPath(*file, text.ImportPath)
+				}
+				docText.Resolved = pkg != nil && (text.Name == "" || packageDeclaresSymbol(pkg, text.Recv, text.Name))
+			}
+			docTexts = append(docTexts, docText)
+		}
+	}
+	return docTexts
+}
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+//commentText returns the text of the comment group, empty for a nil group
+func commentText(commentGroup *CommentGroup) string {
+	if commentGroup == nil {
+		return ""
+	}
+	return commentGroup.Text()
+}
+
+func TestFieldAndSpecComments(t *testing.T) {
+	par := mustParseSource(t, `package p
+
+type Config struct {
+	// Host the address
+	// of the server
+	Host string // required
+	Port int    /* the port */
+	Debug bool
+}
+
+type Store interface {
+	// Get returns the value
+	Get(key string) string // never empty
+	Close() error
+}
+
+const (
+	// Red the first color
+	Red = iota // 0
+	Green
+)
+
+var (
+	// Timeout in seconds
+	Timeout = 5
+	Retries = 3 // attempts
+)
+`)
+
+	config, err := par.GetStruct("Config")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "Config", err)
+	}
+	fieldTests := []struct {
+		name        string
+		wantDoc     string
+		wantComment string
+	}{
+		{name: "Host", wantDoc: "Host the address\nof the server\n", wantComment: "required\n"},
+		{name: "Port", wantComment: " the port\n"},
+		{name: "Debug"},
+	}
+	for i, test := range fieldTests {
+		field := config.Fields[i]
+		if field.Name != test.name {
+			t.Fatalf("Fields[%d].Name = %q, want %q", i, field.Name, test.name)
+		}
+		if got := commentText(field.Doc); got != test.wantDoc {
+			t.Errorf("field %s Doc = %q, want %q", test.name, got, test.wantDoc)
+		}
+		if got := commentText(field.Comment); got != test.wantComment {
+			t.Errorf("field %s Comment = %q, want %q", test.name, got, test.wantComment)
+		}
+	}
+
+	store, err := par.GetInterface("Store")
+	if err != nil {
+		t.Fatalf("GetInterface(%q) error = %v", "Store", err)
+	}
+	methodTests := []struct {
+		name        string
+		wantDoc     string
+		wantComment string
+	}{
+		{name: "Get", wantDoc: "Get returns the value\n", wantComment: "never empty\n"},
+		{name: "Close"},
+	}
+	for i, test := range methodTests {
+		method := store.Methods[i]
+		if method.Name != test.name {
+			t.Fatalf("Methods[%d].Name = %q, want %q", i, method.Name, test.name)
+		}
+		if got := commentText(method.Doc); got != test.wantDoc {
+			t.Errorf("method %s Doc = %q, want %q", test.name, got, test.wantDoc)
+		}
+		if got := commentText(method.Comment); got != test.wantComment {
+			t.Errorf("method %s Comment = %q, want %q", test.name, got, test.wantComment)
+		}
+	}
+
+	specTests := []struct {
+		name        string
+		wantDoc     string
+		wantComment string
+	}{
+		{name: "Red", wantDoc: "Red the first color\n", wantComment: "0\n"},
+		{name: "Green"},
+		{name: "Timeout", wantDoc: "Timeout in seconds\n"},
+		{name: "Retries", wantComment: "attempts\n"},
+	}
+	for _, test := range specTests {
+		variable, err := par.GetVariable(test.name)
+		if err != nil {
+			t.Errorf("GetVariable(%q) error = %v", test.name, err)
+			continue
+		}
+		if got := commentText(variable.Doc); got != test.wantDoc {
+			t.Errorf("GetVariable(%q).Doc = %q, want %q", test.name, got, test.wantDoc)
+		}
+		if got := commentText(variable.Comment); got != test.wantComment {
+			t.Errorf("GetVariable(%q).Comment = %q, want %q", test.name, got, test.wantComment)
+		}
+	}
+}
+
+//commentTexts returns the texts of the comment groups
+func commentTexts(commentGroups []*CommentGroup) (texts []string) {
+	for _, commentGroup := range commentGroups {
+		texts = append(texts, commentText(commentGroup))
+	}
+	return texts
+}
+
+func TestPackageDocAndFloatingComments(t *testing.T) {
+	par := mustParseSources(t, map[string]string{
+		"p/a.go": `// Copyright 2024 The Authors.
+
+// Package p from a.go.
+package p
+
+// floating between the declarations
+
+// A is documented
+type A struct{}
+
+func f() {
+	// inside the body
+}
+
+/* floating at the end */
+`,
+		"p/doc.go": `// Package p does things.
+package p
+`,
+		"q/q.go": `// Package q is only documented here.
+package q
+`,
+		"r/r.go": `package r
+`,
+	})
+
+	packages, err := par.GetPackages()
+	if err != nil {
+		t.Fatalf("GetPackages() error = %v", err)
+	}
+	wantDocs := map[string]string{
+		"p": "Package p does things.\n",
+		"q": "Package q is only documented here.\n",
+		"r": "",
+	}
+	for _, pkg := range packages {
+		if got := commentText(pkg.Doc); got != wantDocs[pkg.Name] {
+			t.Errorf("package %s Doc = %q, want %q", pkg.Name, got, wantDocs[pkg.Name])
+		}
+		if pkg.Name != "p" {
+			continue
+		}
+
+		for _, file := range pkg.Files {
+			if file.Name != "a.go" {
+				continue
+			}
+			if got, want := commentTexts(file.HeaderComments), []string{"Copyright 2024 The Authors.\n"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("HeaderComments = %q, want %q", got, want)
+			}
+			want := []string{"floating between the declarations\n", " floating at the end\n"}
+			if got := commentTexts(file.FloatingComments); !reflect.DeepEqual(got, want) {
+				t.Errorf("FloatingComments = %q, want %q", got, want)
+			}
+			if header := file.HeaderComments[0]; header.Pos.Line != 1 || header.End.Line != 1 {
+				t.Errorf("HeaderComments[0] lines = %d-%d, want 1-1", header.Pos.Line, header.End.Line)
+			}
+		}
+	}
+}
+
+func TestCommentGroupParse(t *testing.T) {
+	par := mustParseSources(t, map[string]string{
+		"go.mod":         "module example.com/app\n",
+		"store/store.go": "package store\n\ntype Store interface{ Get() }\n",
+		"app/app.go": `package app
+
+import "example.com/app/store"
+
+// Service wraps [store.Store] and [Service.Run], see [io.Reader] and [Missing].
+//
+// # Usage
+//
+//   - first
+//   - second
+//
+// Call it like this:
+//
+//	s.Run()
+//
+// Read the [docs].
+//
+// [docs]: https://example.com/docs
+type Service struct{ store store.Store }
+
+func (s *Service) Run() {}
+`,
+	})
+
+	service, err := par.GetStruct("Service")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "Service", err)
+	}
+	doc := service.Doc.Parse()
+
+	blockKinds := []DocBlockKind{}
+	for _, block := range doc.Blocks {
+		blockKinds = append(blockKinds, block.Kind)
+	}
+	wantKinds := []DocBlockKind{ParagraphDocBlock, HeadingDocBlock, ListDocBlock, ParagraphDocBlock, CodeDocBlock, ParagraphDocBlock}
+	if !reflect.DeepEqual(blockKinds, wantKinds) {
+		t.Fatalf("Parse() block kinds = %v, want %v", blockKinds, wantKinds)
+	}
+	if got := len(doc.Blocks[2].Items); got != 2 {
+		t.Errorf("Parse() list items = %d, want 2", got)
+	}
+	if got, want := doc.Blocks[4].Code, "s.Run()\n"; got != want {
+		t.Errorf("Parse() code = %q, want %q", got, want)
+	}
+	if len(doc.Links) != 1 || doc.Links[0].Text != "docs" || doc.Links[0].URL != "https://example.com/docs" || !doc.Links[0].Used {
+		t.Errorf("Parse() links = %+v, want the used docs link", doc.Links)
+	}
+
+	//docLink the target of a doc link and if it's resolved
+	type docLink struct {
+		importPath string
+		recv       string
+		name       string
+		resolved   bool
+	}
+	docLinks := []docLink{}
+	for _, text := range doc.Blocks[0].Text {
+		if text.Kind == DocLinkDocText {
+			docLinks = append(docLinks, docLink{importPath: text.ImportPath, recv: text.Recv, name: text.Name, resolved: text.Resolved})
+		}
+	}
+	wantLinks := []docLink{
+		{importPath: "example.com/app/store", name: "Store", resolved: true},
+		{importPath: "example.com/app/app", recv: "Service", name: "Run", resolved: true},
+		{importPath: "io", name: "Reader"},
+	}
+	if !reflect.DeepEqual(docLinks, wantLinks) {
+		t.Errorf("Parse() doc links = %+v, want %+v", docLinks, wantLinks)
+	}
+}
@@ -0,0 +1,418 @@
+package parser
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"math/big"
+)
+
+//collectPackageConstants collects all the constants of the package with the implicit repetition applied,
+//a constant without a value repeats the value and the type of the previous spec in the same group.
+//All the constants are evaluated once here, after that they are only read so the parser can be used concurrently
+func collectPackageConstants(pkg *parserPackage) {
+	pkg.Constants = map[string]*parserConstant{}
+	pkg.ConstantsByIdent = map[*ast.Ident]*parserConstant{}
+	pkg.TypeSpecs = map[string]*ast.TypeSpec{}
+
+	for _, file := range pkg.GoFiles {
+		for _, genDecl := range parseGenDeclarations(*file) {
+			switch genDecl.Tok {
+			case token.TYPE:
+				for _, spec := range genDecl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					pkg.TypeSpecs[typeSpec.Name.Name] = typeSpec
+				}
+			case token.CONST:
+				var previousValues []ast.Expr
+				var previousType ast.Expr
+				for iota, spec := range genDecl.Specs {
+					valueSpec := spec.(*ast.ValueSpec)
+					if len(valueSpec.Values) != 0 {
+						previousValues = valueSpec.Values
+						previousType = valueSpec.Type
+					}
+
+					for i, name := range valueSpec.Names {
+						theConstant := &parserConstant{
+							Name: name.Name,
+							Type: previousType,
+							Iota: iota,
+						}
+						if i < len(previousValues) {
+							theConstant.Expression = previousValues[i]
+						}
+
+						pkg.ConstantsByIdent[name] = theConstant
+						if name.Name != "_" {
+							pkg.Constants[name.Name] = theConstant
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// the types have to be known before evaluating, the operations on unsigned constants depend on them
+	for _, theConstant := range pkg.ConstantsByIdent {
+		if theConstant.Type == nil && theConstant.Expression != nil {
+			theConstant.Type = conversionType(pkg, theConstant.Expression)
+		}
+	}
+	for _, theConstant := range pkg.ConstantsByIdent {
+		evaluateConstant(pkg, theConstant)
+	}
+}
+
+//constantExpressionTypeLimit limits the constants followed while looking for the type of a constant expression,
+//constants that depend on themselves are invalid go code
+const constantExpressionTypeLimit = 32
+
+//conversionType returns the type declared in the package a constant expression without a type has,
+//Status for Status(iota), ^Size(0), Size(1) << iota or Active + 1, returns nil if the expression is untyped
+func conversionType(pkg *parserPackage, expression ast.Expr) ast.Expr {
+	return typedOperandType(pkg, expression, 0)
+}
+
+//typedOperandType returns the type of the typed operand of the expression, the conversions, the typed constants
+//and the unary and the binary expressions with a typed operand are typed
+func typedOperandType(pkg *parserPackage, expression ast.Expr, depth int) ast.Expr {
+	if depth > constantExpressionTypeLimit {
+		return nil
+	}
+	switch expr := unparen(expression).(type) {
+	case *ast.CallExpr:
+		if len(expr.Args) != 1 {
+			return nil
+		}
+		ident, ok := unparen(expr.Fun).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		if _, ok := pkg.TypeSpecs[ident.Name]; !ok {
+			return nil
+		}
+		return ident
+	case *ast.Ident:
+		theConstant, ok := pkg.Constants[expr.Name]
+		if !ok {
+			return nil
+		}
+		if theConstant.Type != nil {
+			return theConstant.Type
+		}
+		if theConstant.Expression == nil {
+			return nil
+		}
+		return typedOperandType(pkg, theConstant.Expression, depth+1)
+	case *ast.UnaryExpr:
+		return typedOperandType(pkg, expr.X, depth+1)
+	case *ast.BinaryExpr:
+		switch expr.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ, token.LAND, token.LOR:
+			// comparisons are untyped booleans
+			return nil
+		case token.SHL, token.SHR:
+			// shifts have the type of the left operand
+			return typedOperandType(pkg, expr.X, depth+1)
+		}
+		if typeExpr := typedOperandType(pkg, expr.X, depth+1); typeExpr != nil {
+			return typeExpr
+		}
+		return typedOperandType(pkg, expr.Y, depth+1)
+	}
+	return nil
+}
+
+//unparen removes the parentheses around the expression, (Status)(1) converts into Status
+func unparen(expression ast.Expr) ast.Expr {
+	for {
+		parenExpr, ok := expression.(*ast.ParenExpr)
+		if !ok {
+			return expression
+		}
+		expression = parenExpr.X
+	}
+}
+
+//evaluateConstant evaluates the constant with go/constant semantics, the value is unknown
+//if the constant depends on something that can't be evaluated, like constants from other packages.
+//The value is only computed while the package constants are collected
+func evaluateConstant(pkg *parserPackage, theConstant *parserConstant) constant.Value {
+	if theConstant.evaluated {
+		return theConstant.value
+	}
+	// constants that depend on themselves are invalid go code
+	if theConstant.evaluating || theConstant.Expression == nil {
+		return constant.MakeUnknown()
+	}
+
+	theConstant.evaluating = true
+	value := evaluateConstantExpression(pkg, theConstant.Expression, theConstant.Iota)
+	if theConstant.Type != nil {
+		value = convertConstantToType(pkg, value, theConstant.Type)
+	}
+	theConstant.evaluating = false
+
+	theConstant.value = value
+	theConstant.evaluated = true
+	return value
+}
+
+//evaluateConstantExpression evaluates the expression of a constant with the provided iota
+func evaluateConstantExpression(pkg *parserPackage, expression ast.Expr, iota int) (value constant.Value) {
+	// go/constant panics on operations with mismatched kinds, they are invalid go code
+	defer func() {
+		if recover() != nil {
+			value = constant.MakeUnknown()
+		}
+	}()
+
+	switch expression := expression.(type) {
+	case *ast.BasicLit:
+		return constant.MakeFromLiteral(expression.Value, expression.Kind, 0)
+	case *ast.ParenExpr:
+		return evaluateConstantExpression(pkg, expression.X, iota)
+	case *ast.Ident:
+		switch expression.Name {
+		case "iota":
+			return constant.MakeInt64(int64(iota))
+		case "true":
+			return constant.MakeBool(true)
+		case "false":
+			return constant.MakeBool(false)
+		}
+		if theConstant, ok := pkg.Constants[expression.Name]; ok {
+			return evaluateConstant(pkg, theConstant)
+		}
+		return constant.MakeUnknown()
+	case *ast.UnaryExpr:
+		x := evaluateConstantExpression(pkg, expression.X, iota)
+		if x.Kind() == constant.Unknown {
+			return x
+		}
+		// the complement of an unsigned constant is limited to the size of its type, ^uint8(0) is 255
+		precision := unsignedPrecision(resolveBasicTypeName(pkg, constantExpressionType(pkg, expression.X, 0), 0))
+		return constant.UnaryOp(expression.Op, x, precision)
+	case *ast.BinaryExpr:
+		x := evaluateConstantExpression(pkg, expression.X, iota)
+		y := evaluateConstantExpression(pkg, expression.Y, iota)
+		if x.Kind() == constant.Unknown || y.Kind() == constant.Unknown {
+			return constant.MakeUnknown()
+		}
+		return evaluateConstantBinaryOp(x, expression.Op, y)
+	case *ast.CallExpr:
+		return evaluateConstantCall(pkg, expression, iota)
+	default:
+		return constant.MakeUnknown()
+	}
+}
+
+//constantExpressionType returns the type expression of a typed constant expression,
+//returns nil for the untyped expressions
+func constantExpressionType(pkg *parserPackage, expression ast.Expr, depth int) ast.Expr {
+	if depth > len(pkg.Constants) {
+		return nil
+	}
+
+	switch expression := expression.(type) {
+	case *ast.ParenExpr:
+		return constantExpressionType(pkg, expression.X, depth)
+	case *ast.CallExpr:
+		if ident, ok := expression.Fun.(*ast.Ident); ok && ident.Name == "len" {
+			return nil
+		}
+		return unparen(expression.Fun)
+	case *ast.Ident:
+		theConstant, ok := pkg.Constants[expression.Name]
+		if !ok {
+			return nil
+		}
+		if theConstant.Type != nil {
+			return theConstant.Type
+		}
+		if theConstant.Expression == nil {
+			return nil
+		}
+		return constantExpressionType(pkg, theConstant.Expression, depth+1)
+	case *ast.UnaryExpr:
+		return constantExpressionType(pkg, expression.X, depth)
+	case *ast.BinaryExpr:
+		switch expression.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ, token.LAND, token.LOR:
+			return nil
+		case token.SHL, token.SHR:
+			return constantExpressionType(pkg, expression.X, depth)
+		}
+		if typeExpression := constantExpressionType(pkg, expression.X, depth); typeExpression != nil {
+			return typeExpression
+		}
+		return constantExpressionType(pkg, expression.Y, depth)
+	default:
+		return nil
+	}
+}
+
+//unsignedPrecision returns the size in bits of the unsigned basic type, 0 for all the other types
+func unsignedPrecision(basicTypeName string) uint {
+	switch basicTypeName {
+	case "uint8", "byte":
+		return 8
+	case "uint16":
+		return 16
+	case "uint32":
+		return 32
+	case "uint", "uint64", "uintptr":
+		return 64
+	default:
+		return 0
+	}
+}
+
+//evaluateConstantBinaryOp evaluates the binary operation, x << y, x / y, x == y...
+func evaluateConstantBinaryOp(x constant.Value, op token.Token, y constant.Value) constant.Value {
+	switch op {
+	case token.SHL, token.SHR:
+		shift, ok := constant.Uint64Val(constant.ToInt(y))
+		if !ok {
+			return constant.MakeUnknown()
+		}
+		return constant.Shift(constant.ToInt(x), op, uint(shift))
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return constant.MakeBool(constant.Compare(x, op, y))
+	case token.QUO, token.REM:
+		if constant.Sign(y) == 0 {
+			return constant.MakeUnknown()
+		}
+		// division of integer constants is an integer division
+		if op == token.QUO && x.Kind() == constant.Int && y.Kind() == constant.Int {
+			op = token.QUO_ASSIGN
+		}
+	}
+	return constant.BinaryOp(x, op, y)
+}
+
+//evaluateConstantCall evaluates the conversions, Status(1), and the len builtin on constant strings
+func evaluateConstantCall(pkg *parserPackage, call *ast.CallExpr, iota int) constant.Value {
+	if len(call.Args) != 1 {
+		return constant.MakeUnknown()
+	}
+	arg := evaluateConstantExpression(pkg, call.Args[0], iota)
+	if arg.Kind() == constant.Unknown {
+		return arg
+	}
+
+	if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "len" {
+		if arg.Kind() != constant.String {
+			return constant.MakeUnknown()
+		}
+		return constant.MakeInt64(int64(len(constant.StringVal(arg))))
+	}
+	return convertConstantToType(pkg, arg, call.Fun)
+}
+
+//convertConstantToType converts the value into the basic type the type expression resolves to,
+//named types are resolved through the type declarations of the package
+func convertConstantToType(pkg *parserPackage, value constant.Value, typeExpression ast.Expr) constant.Value {
+	switch resolveBasicTypeName(pkg, typeExpression, 0) {
+	case "float32", "float64":
+		return constant.ToFloat(value)
+	case "complex64", "complex128":
+		return constant.ToComplex(value)
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte", "rune":
+		return constant.ToInt(value)
+	case "string":
+		// string(65) converts the code point into a string
+		if value.Kind() == constant.Int {
+			codePoint, ok := constant.Int64Val(value)
+			if !ok {
+				return constant.MakeUnknown()
+			}
+			return constant.MakeString(string(rune(codePoint)))
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+//resolveBasicTypeName follows the named types declared in the package until it reaches a basic type,
+//returns empty string if the type is not a basic type or can't be resolved
+func resolveBasicTypeName(pkg *parserPackage, typeExpression ast.Expr, depth int) string {
+	ident, ok := typeExpression.(*ast.Ident)
+	if !ok || depth > len(pkg.TypeSpecs) {
+		return ""
+	}
+
+	if typeSpec, ok := pkg.TypeSpecs[ident.Name]; ok {
+		return resolveBasicTypeName(pkg, typeSpec.Type, depth+1)
+	}
+	if isBasicDataType(ident.Name) {
+		return ident.Name
+	}
+	return ""
+}
+
+//formatConstantValue converts the evaluated value into its string representation and kind,
+//string constants are unquoted, returns nil if the value is unknown
+func formatConstantValue(value constant.Value) (theValue *string, kind string) {
+	formatedValue := ""
+	switch value.Kind() {
+	case constant.Bool:
+		formatedValue, kind = value.String(), "bool"
+	case constant.String:
+		formatedValue, kind = constant.StringVal(value), "string"
+	case constant.Int:
+		formatedValue, kind = value.ExactString(), "int"
+	case constant.Float:
+		formatedValue, kind = formatFloatConstant(value), "float"
+	case constant.Complex:
+		formatedValue, kind = value.String(), "complex"
+	default:
+		return nil, ""
+	}
+	return &formatedValue, kind
+}
+
+//formatFloatConstant formats the float constant without losing precision, the values that have
+//a finite decimal representation are written as decimals and the other ones as fractions, 1/3
+func formatFloatConstant(value constant.Value) string {
+	switch floatValue := constant.Val(value).(type) {
+	case *big.Float:
+		return floatValue.Text('g', -1)
+	case *big.Rat:
+		if floatValue.IsInt() {
+			precision := uint(floatValue.Num().BitLen())
+			if precision < 64 {
+				precision = 64
+			}
+			return new(big.Float).SetPrec(precision).SetInt(floatValue.Num()).Text('g', -1)
+		}
+		if digits, ok := decimalDigits(floatValue.Denom()); ok {
+			return floatValue.FloatString(digits)
+		}
+	}
+	return value.ExactString()
+}
+
+//decimalDigits returns the number of decimal digits needed to write a fraction with the denominator exactly,
+//only the denominators with no prime factors other than 2 and 5 have a finite decimal representation
+func decimalDigits(denominator *big.Int) (digits int, ok bool) {
+	remainder := new(big.Int).Set(denominator)
+	for _, factor := range []int64{2, 5} {
+		divisor := big.NewInt(factor)
+		count := 0
+		for {
+			quotient, modulo := new(big.Int).QuoRem(remainder, divisor, new(big.Int))
+			if modulo.Sign() != 0 {
+				break
+			}
+			remainder = quotient
+			count++
+		}
+		if count > digits {
+			digits = count
+		}
+	}
+	return digits, remainder.Cmp(big.NewInt(1)) == 0
+}
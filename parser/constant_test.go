@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+const constantsSource = `package p
+
+type Status uint8
+
+type Weekday int
+
+type Size uint16
+
+const (
+	KB = 1 << (10 * (iota + 1))
+	MB
+	GB
+)
+
+const (
+	Active = Status(iota)
+	Inactive
+	_
+	Deleted
+)
+
+const (
+	Sunday Weekday = iota
+	Monday
+)
+
+const Tuesday = Weekday(2)
+
+const (
+	Mask     = ^uint8(0)
+	MaxU     = ^uint(0)
+	MaxU16   = ^uint16(0)
+	MaxU32   = ^uint32(0)
+	Negative = ^0
+	Complete = ^Deleted
+)
+
+const (
+	MaxSize = ^Size(0)
+	Small   = Size(1) << iota
+	Large
+	IsSmall = Small < Large
+	Medium  = (Small + Large) / 2
+)
+
+const (
+	Pi    = 3.14159265358979323846264338327950288419716939937510582097494459
+	Third = 1.0 / 3
+	Big   = 1e300 * 1e300
+	Half  = 0.5
+)
+`
+
+func TestConstantValues(t *testing.T) {
+	par := mustParseSource(t, constantsSource)
+
+	tests := []struct {
+		name      string
+		wantValue string
+		wantKind  string
+		wantType  string
+		wantIota  int
+	}{
+		{name: "KB", wantValue: "1024", wantKind: "int", wantIota: 0},
+		{name: "MB", wantValue: "1048576", wantKind: "int", wantIota: 1},
+		{name: "GB", wantValue: "1073741824", wantKind: "int", wantIota: 2},
+		{name: "Active", wantValue: "0", wantKind: "int", wantType: "Status", wantIota: 0},
+		{name: "Inactive", wantValue: "1", wantKind: "int", wantType: "Status", wantIota: 1},
+		{name: "Deleted", wantValue: "3", wantKind: "int", wantType: "Status", wantIota: 3},
+		{name: "Monday", wantValue: "1", wantKind: "int", wantType: "Weekday", wantIota: 1},
+		{name: "Tuesday", wantValue: "2", wantKind: "int", wantType: "Weekday"},
+		{name: "Mask", wantValue: "255", wantKind: "int"},
+		{name: "MaxU", wantValue: "18446744073709551615", wantKind: "int", wantIota: 1},
+		{name: "MaxU16", wantValue: "65535", wantKind: "int", wantIota: 2},
+		{name: "MaxU32", wantValue: "4294967295", wantKind: "int", wantIota: 3},
+		{name: "Negative", wantValue: "-1", wantKind: "int", wantIota: 4},
+		{name: "Complete", wantValue: "252", wantKind: "int", wantType: "Status", wantIota: 5},
+		{name: "MaxSize", wantValue: "65535", wantKind: "int", wantType: "Size"},
+		{name: "Small", wantValue: "2", wantKind: "int", wantType: "Size", wantIota: 1},
+		{name: "Large", wantValue: "4", wantKind: "int", wantType: "Size", wantIota: 2},
+		{name: "IsSmall", wantValue: "true", wantKind: "bool", wantIota: 3},
+		{name: "Medium", wantValue: "3", wantKind: "int", wantType: "Size", wantIota: 4},
+		{name: "Pi", wantValue: "3.14159265358979323846264338327950288419716939937510582097494459", wantKind: "float"},
+		{name: "Third", wantValue: "1/3", wantKind: "float", wantIota: 1},
+		{name: "Big", wantValue: "1e+600", wantKind: "float", wantIota: 2},
+		{name: "Half", wantValue: "0.5", wantKind: "float", wantIota: 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			variable, err := par.GetVariable(test.name)
+			if err != nil {
+				t.Fatalf("GetVariable(%q) error = %v", test.name, err)
+			}
+			if variable.ConstValue == nil {
+				t.Fatalf("GetVariable(%q).ConstValue = nil, want %q", test.name, test.wantValue)
+			}
+			if *variable.ConstValue != test.wantValue || variable.ConstKind != test.wantKind {
+				t.Errorf("GetVariable(%q) value = %s %s, want %s %s",
+					test.name, *variable.ConstValue, variable.ConstKind, test.wantValue, test.wantKind)
+			}
+			if variable.Type != test.wantType {
+				t.Errorf("GetVariable(%q).Type = %q, want %q", test.name, variable.Type, test.wantType)
+			}
+			if variable.Iota != test.wantIota {
+				t.Errorf("GetVariable(%q).Iota = %d, want %d", test.name, variable.Iota, test.wantIota)
+			}
+		})
+	}
+}
+
+func TestConversionTypedEnums(t *testing.T) {
+	par := mustParseSource(t, constantsSource)
+
+	tests := []struct {
+		name        string
+		wantMembers []string
+	}{
+		{name: "Status", wantMembers: []string{"Active", "Inactive", "Deleted", "Complete"}},
+		{name: "Size", wantMembers: []string{"MaxSize", "Small", "Large", "Medium"}},
+		{name: "Weekday", wantMembers: []string{"Sunday", "Monday", "Tuesday"}},
+	}
+	for _, test := range tests {
+		enum, err := par.GetEnum(test.name)
+		if err != nil {
+			t.Errorf("GetEnum(%q) error = %v", test.name, err)
+			continue
+		}
+		members := []string{}
+		for _, member := range enum.Members {
+			members = append(members, member.Name)
+		}
+		if !reflect.DeepEqual(members, test.wantMembers) {
+			t.Errorf("GetEnum(%q).Members = %v, want %v", test.name, members, test.wantMembers)
+		}
+	}
+}
+
+func TestConcurrentConstantAccess(t *testing.T) {
+	par := mustParseSource(t, constantsSource)
+
+	var wg sync.WaitGroup
+	values := make([][]Variable, 8)
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			consts, err := par.GetConstantVariables()
+			if err != nil {
+				t.Errorf("GetConstantVariables() error = %v", err)
+				return
+			}
+			values[i] = consts
+
+			if _, err := par.GetEnums(); err != nil {
+				t.Errorf("GetEnums() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, consts := range values {
+		for _, theConst := range consts {
+			if theConst.ConstValue == nil {
+				t.Errorf("goroutine %d: constant %s has no value", i, theConst.Name)
+			}
+		}
+	}
+}
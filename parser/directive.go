@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"go/ast"
+	"regexp"
+	"strings"
+)
+
+//DirectiveSyntax recognizes a directive in a single comment, the text of the comment includes the comment marker,
+//returns false if the comment is not a directive of the syntax
+type DirectiveSyntax func(text string) (directive Directive, ok bool)
+
+var (
+	goDirectiveRegex     = regexp.MustCompile(`^//[a-z0-9]+:[a-z0-9]`)
+	nolintDirectiveRegex = regexp.MustCompile(`^// ?nolint(:\S+)?(\s|$)`)
+)
+
+//DefaultDirectiveSyntaxes returns the syntaxes used when the parser options don't provide any,
+//the go directives, the nolint directives and the annotations starting with + or @
+func DefaultDirectiveSyntaxes() []DirectiveSyntax {
+	return []DirectiveSyntax{
+		NolintDirectiveSyntax,
+		GoDirectiveSyntax,
+		AnnotationSyntax("+"),
+		AnnotationSyntax("@"),
+	}
+}
+
+//GoDirectiveSyntax recognizes the go style directives without the space after the comment marker,
+//the go:generate directive of mockgen -source=store.go has the arguments mockgen and -source=store.go
+func GoDirectiveSyntax(text string) (directive Directive, ok bool) {
+	if !goDirectiveRegex.MatchString(text) {
+		return directive, false
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(text, "//"))
+	return Directive{Name: fields[0], Args: fields[1:]}, true
+}
+
+//NolintDirectiveSyntax recognizes the golangci-lint nolint directives, //nolint:errcheck,gosec // reason
+//has the name nolint and the linters errcheck and gosec as the arguments
+func NolintDirectiveSyntax(text string) (directive Directive, ok bool) {
+	match := nolintDirectiveRegex.FindStringSubmatch(text)
+	if match == nil {
+		return directive, false
+	}
+
+	directive = Directive{Name: "nolint"}
+	if linters := strings.TrimPrefix(match[1], ":"); linters != "" {
+		directive.Args = strings.Split(linters, ",")
+	}
+	return directive, true
+}
+
+//AnnotationSyntax returns the syntax of the annotations starting with the marker, for the marker @
+//the comment // @route GET /users has the name route and the arguments GET and /users
+func AnnotationSyntax(marker string) DirectiveSyntax {
+	return func(text string) (directive Directive, ok bool) {
+		if !strings.HasPrefix(text, "//") {
+			return directive, false
+		}
+		annotation := strings.TrimPrefix(strings.TrimPrefix(text, "//"), " ")
+		if !strings.HasPrefix(annotation, marker) {
+			return directive, false
+		}
+
+		fields := strings.Fields(strings.TrimPrefix(annotation, marker))
+		if len(fields) == 0 || strings.HasPrefix(annotation, marker+" ") {
+			return directive, false
+		}
+		return Directive{Name: fields[0], Args: fields[1:]}, true
+	}
+}
+
+//parseDirectives returns the directives from the line comments of the comment groups,
+//each comment is matched against the directive syntaxes of the parser in order
+func parseDirectives(file parserGoFile, astCommentGroups ...*ast.CommentGroup) (directives []Directive) {
+	syntaxes := DefaultDirectiveSyntaxes()
+	if file.Package != nil && file.Package.Parser != nil && file.Package.Parser.directiveSyntaxes != nil {
+		syntaxes = file.Package.Parser.directiveSyntaxes
+	}
+
+	for _, astCommentGroup := range astCommentGroups {
+		if astCommentGroup == nil {
+			continue
+		}
+		for _, astComment := range astCommentGroup.List {
+			for _, syntax := range syntaxes {
+				directive, ok := syntax(astComment.Text)
+				if !ok {
+					continue
+				}
+				directive.Raw = astComment.Text
+				directive.Pos = parsePosition(file, astComment.Pos())
+				directives = append(directives, directive)
+				break
+			}
+		}
+	}
+	return directives
+}
+
+//parseFileDirectives returns the directives of the file, from the comments above the package clause,
+//the package doc and the comments that don't belong to any declaration
+func parseFileDirectives(file parserGoFile) []Directive {
+	astCommentGroups := []*ast.CommentGroup{}
+	for _, astCommentGroup := range file.AstFile.Comments {
+		if astCommentGroup.Pos() < file.AstFile.Package {
+			astCommentGroups = append(astCommentGroups, astCommentGroup)
+		}
+	}
+	astCommentGroups = append(astCommentGroups, parseFloatingCommentGroups(file)...)
+	return parseDirectives(file, astCommentGroups...)
+}
+
+//hasDirective checks if one of the directives has the name
+func hasDirective(directives []Directive, name string) bool {
+	for _, directive := range directives {
+		if directive.Name == name {
+			return true
+		}
+	}
+	return false
+}
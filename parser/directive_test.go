@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDirectiveSyntaxes(t *testing.T) {
+	tests := []struct {
+		text     string
+		wantName string
+		wantArgs []string
+		wantOk   bool
+	}{
+		{text: "//go:generate mockgen -source=store.go", wantName: "go:generate", wantArgs: []string{"mockgen", "-source=store.go"}, wantOk: true},
+		{text: "//go:embed static/*", wantName: "go:embed", wantArgs: []string{"static/*"}, wantOk: true},
+		{text: "//go:linkname now runtime.now", wantName: "go:linkname", wantArgs: []string{"now", "runtime.now"}, wantOk: true},
+		{text: "//nolint", wantName: "nolint", wantOk: true},
+		{text: "//nolint:errcheck,gosec // reason", wantName: "nolint", wantArgs: []string{"errcheck", "gosec"}, wantOk: true},
+		{text: "// nolint:lll", wantName: "nolint", wantArgs: []string{"lll"}, wantOk: true},
+		{text: "// +gen:mock", wantName: "gen:mock", wantArgs: []string{}, wantOk: true},
+		{text: "//+k8s:deepcopy-gen=true", wantName: "k8s:deepcopy-gen=true", wantArgs: []string{}, wantOk: true},
+		{text: "// @route GET /users", wantName: "route", wantArgs: []string{"GET", "/users"}, wantOk: true},
+		{text: "// go:generate is not a directive with the space"},
+		{text: "// + not an annotation"},
+		{text: "// a plain comment"},
+		{text: "/* @route GET /users */"},
+		{text: "//nolintx"},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			var directive Directive
+			ok := false
+			for _, syntax := range DefaultDirectiveSyntaxes() {
+				if directive, ok = syntax(test.text); ok {
+					break
+				}
+			}
+			if ok != test.wantOk {
+				t.Fatalf("directive ok = %t, want %t", ok, test.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if directive.Name != test.wantName || !reflect.DeepEqual(directive.Args, test.wantArgs) {
+				t.Errorf("directive = %s %q, want %s %q", directive.Name, directive.Args, test.wantName, test.wantArgs)
+			}
+		})
+	}
+}
+
+const directivesSource = `//go:build linux
+
+//go:generate stringer -type=Status
+package p
+
+// User is mocked
+// +gen:mock
+type User struct {
+	Name string // +optional
+}
+
+// @entity users
+type Account struct{}
+
+type Plain struct{}
+
+//nolint:unused
+var unused int
+`
+
+func TestGetStructsByDirective(t *testing.T) {
+	par := mustParseSource(t, directivesSource)
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "gen:mock", want: []string{"User"}},
+		{name: "entity", want: []string{"Account"}},
+		{name: "optional"},
+		{name: "missing"},
+	}
+	for _, test := range tests {
+		structs, err := par.GetStructsByDirective(test.name)
+		if err != nil {
+			t.Fatalf("GetStructsByDirective(%q) error = %v", test.name, err)
+		}
+		names := []string{}
+		for _, theStruct := range structs {
+			names = append(names, theStruct.Name)
+		}
+		if test.want == nil {
+			test.want = []string{}
+		}
+		if !reflect.DeepEqual(names, test.want) {
+			t.Errorf("GetStructsByDirective(%q) = %v, want %v", test.name, names, test.want)
+		}
+	}
+
+	user, err := par.GetStruct("User")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "User", err)
+	}
+	if directives := user.Fields[0].Directives; len(directives) != 1 || directives[0].Name != "optional" {
+		t.Errorf("field Name Directives = %+v, want optional", directives)
+	}
+	variable, err := par.GetVariable("unused")
+	if err != nil {
+		t.Fatalf("GetVariable(%q) error = %v", "unused", err)
+	}
+	if directives := variable.Directives; len(directives) != 1 || directives[0].Name != "nolint" {
+		t.Errorf("GetVariable(%q).Directives = %+v, want nolint", "unused", directives)
+	}
+
+	packages, err := par.GetPackages()
+	if err != nil {
+		t.Fatalf("GetPackages() error = %v", err)
+	}
+	fileDirectives := []string{}
+	for _, directive := range packages[0].Files[0].Directives {
+		fileDirectives = append(fileDirectives, directive.Raw)
+	}
+	if want := []string{"//go:build linux", "//go:generate stringer -type=Status"}; !reflect.DeepEqual(fileDirectives, want) {
+		t.Errorf("file Directives = %q, want %q", fileDirectives, want)
+	}
+}
+
+func TestCustomDirectiveSyntaxes(t *testing.T) {
+	//kubebuilderSyntax recognizes the // +kubebuilder: markers only
+	kubebuilderSyntax := func(text string) (directive Directive, ok bool) {
+		if !strings.HasPrefix(text, "// +kubebuilder:") {
+			return directive, false
+		}
+		return Directive{Name: "kubebuilder", Args: strings.Split(strings.TrimPrefix(text, "// +kubebuilder:"), ":")}, true
+	}
+	fsys := fstest.MapFS{"p/p.go": {Data: []byte(`package p
+
+// +kubebuilder:object:root=true
+// +gen:mock
+type Resource struct{}
+`)}}
+	_, par, err := NewParserFS(fsys, ".", ParserOptions{Recursive: true, DirectiveSyntaxes: []DirectiveSyntax{kubebuilderSyntax}})
+	if err != nil {
+		t.Fatalf("NewParserFS() error = %v", err)
+	}
+
+	resource, err := par.GetStruct("Resource")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "Resource", err)
+	}
+	if len(resource.Directives) == 0 {
+		t.Fatal("Directives is empty, want the kubebuilder directive")
+	}
+	want := []Directive{{
+		Name: "kubebuilder",
+		Args: []string{"object", "root=true"},
+		Raw:  "// +kubebuilder:object:root=true",
+		Pos:  resource.Directives[0].Pos,
+	}}
+	if !reflect.DeepEqual(resource.Directives, want) {
+		t.Errorf("Directives = %+v, want %+v", resource.Directives, want)
+	}
+}
@@ -0,0 +1,271 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+//maxTypeResolutionDepth limits how many aliases and defined types are followed while resolving a type
+const maxTypeResolutionDepth = 32
+
+//typeKey identifies a named type across all the parsed packages
+type typeKey struct {
+	pkg  *parserPackage
+	name string
+}
+
+//resolvedType is a named type from the parsed packages with the members it brings when it's embedded
+type resolvedType struct {
+	key     typeKey
+	fields  []*Field
+	methods []*Method
+	// embeds the embedded interfaces and typeSet the type set terms, only interfaces have them
+	embeds      []*TypeExpr
+	typeSet     []*TypeExpr
+	isInterface bool
+	// file the fields are declared in, the types of the fields are resolved from it
+	file parserGoFile
+}
+
+//embeddedEntry is an embedded field reached while walking the embedding chain of a struct
+type embeddedEntry struct {
+	file     parserGoFile
+	field    *Field
+	path     []string
+	indirect bool
+}
+
+//PromotedFields returns the fields of the embedded fields that can be selected directly on the struct,
+//following the selector rules of the go spec, the shallowest depth wins and the names declared more than
+//once at the same depth are ambiguous and not promoted. Only the embedded types declared in the parsed
+//packages are resolved
+func (s Struct) PromotedFields() (fields []*PromotedField, err error) {
+	fields, _, err = s.promotedMembers()
+	return fields, err
+}
+
+//PromotedMethods returns the methods of the embedded fields that can be called directly on the struct,
+//following the same rules as PromotedFields
+func (s Struct) PromotedMethods() (methods []*PromotedMethod, err error) {
+	_, methods, err = s.promotedMembers()
+	return methods, err
+}
+
+func (s Struct) promotedMembers() (fields []*PromotedField, methods []*PromotedMethod, err error) {
+	if s.file == nil {
+		return nil, nil, nil
+	}
+
+	return promotedMembers(&resolvedType{
+		key:     typeKey{pkg: s.file.Package, name: s.Name},
+		fields:  s.Fields,
+		methods: s.Methods,
+		file:    *s.file,
+	})
+}
+
+//promotedMembers walks the embedded fields of the type breadth first, one depth at the time
+func promotedMembers(theType *resolvedType) (fields []*PromotedField, methods []*PromotedMethod, err error) {
+	// fields and methods share the same names, the names from the shallower depths shadow the deeper ones
+	shadowed := map[string]bool{}
+	level := []embeddedEntry{}
+	for _, field := range theType.fields {
+		shadowed[field.Name] = true
+		if field.Embedded {
+			level = append(level, embeddedEntry{file: theType.file, field: field})
+		}
+	}
+	for _, method := range theType.methods {
+		shadowed[method.Name] = true
+	}
+
+	seen := map[typeKey]bool{theType.key: true}
+	for len(level) != 0 {
+		names := []string{}
+		promotedFields := map[string][]*PromotedField{}
+		promotedMethods := map[string][]*PromotedMethod{}
+		levelKeys := []typeKey{}
+		nextLevel := []embeddedEntry{}
+
+		for _, entry := range level {
+			resolved, pointer, err := resolveEmbeddedType(entry.file, entry.field.TypeExpr)
+			if err != nil {
+				return nil, nil, err
+			}
+			// the embedded type is not parsed or it was already reached at a shallower depth
+			if resolved == nil || seen[resolved.key] {
+				continue
+			}
+			levelKeys = append(levelKeys, resolved.key)
+
+			path := append(append([]string{}, entry.path...), entry.field.Name)
+			indirect := entry.indirect || pointer
+			for _, field := range resolved.fields {
+				if field.Name == "_" {
+					continue
+				}
+				names = append(names, field.Name)
+				promotedFields[field.Name] = append(promotedFields[field.Name],
+					&PromotedField{Field: field, Path: path, Indirect: indirect})
+				if field.Embedded {
+					nextLevel = append(nextLevel, embeddedEntry{file: resolved.file, field: field, path: path, indirect: indirect})
+				}
+			}
+			resolvedMethods, err := interfaceMethodSet(resolved.file, resolved.methods, resolved.embeds,
+				map[typeKey]bool{resolved.key: true})
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, method := range resolvedMethods {
+				names = append(names, method.Name)
+				promotedMethods[method.Name] = append(promotedMethods[method.Name],
+					&PromotedMethod{Method: method, Path: path, Indirect: indirect})
+			}
+		}
+
+		for _, name := range names {
+			if shadowed[name] || len(promotedFields[name])+len(promotedMethods[name]) != 1 {
+				continue
+			}
+			fields = append(fields, promotedFields[name]...)
+			methods = append(methods, promotedMethods[name]...)
+		}
+		for _, name := range names {
+			shadowed[name] = true
+		}
+		for _, key := range levelKeys {
+			seen[key] = true
+		}
+		level = nextLevel
+	}
+	return fields, methods, nil
+}
+
+//resolveEmbeddedType finds the declaration of the embedded type in the parsed packages,
+//returns nil if the type is not declared in the parsed packages
+func resolveEmbeddedType(file parserGoFile, typeExpr *TypeExpr) (resolved *resolvedType, pointer bool, err error) {
+	if typeExpr.Kind == PointerTypeExpr {
+		pointer = true
+		typeExpr = typeExpr.Elem
+	}
+
+	resolved, err = resolveNamedType(file, typeExpr, 0)
+	return resolved, pointer, err
+}
+
+//resolveNamedType finds the declaration of the named type, the qualifier of the type is resolved
+//from the imports of the file
+func resolveNamedType(file parserGoFile, typeExpr *TypeExpr, depth int) (*resolvedType, error) {
+	if typeExpr.Kind != NamedTypeExpr || depth > maxTypeResolutionDepth {
+		return nil, nil
+	}
+
+	pkg := file.Package
+	if typeExpr.ImportPath != "" {
+		pkg = findPackageByImportPath(file, typeExpr.ImportPath)
+		if pkg == nil {
+			pkg = wellKnownPackage(typeExpr.ImportPath)
+		}
+	} else if typeExpr.Package != "" {
+		return nil, nil
+	} else if _, ok := pkg.TypeSpecs[typeExpr.Name]; !ok {
+		// error is the only predeclared type with methods
+		pkg = wellKnownPackage(builtinImportPath)
+	}
+	if pkg == nil {
+		return nil, nil
+	}
+	return lookupPackageType(pkg, typeExpr.Name, depth)
+}
+
+//lookupPackageType returns the type declaration with the provided name from the package,
+//aliases are followed and defined types get the fields of the underlying struct type.
+//The types are converted once per parser and cached
+func lookupPackageType(pkg *parserPackage, name string, depth int) (*resolvedType, error) {
+	key := typeKey{pkg: pkg, name: name}
+	cache := packageCache(pkg)
+	if resolved, ok := cache.resolvedType(key); ok {
+		return resolved, nil
+	}
+
+	resolved, err := convertPackageType(pkg, name, depth)
+	if err != nil {
+		return nil, err
+	}
+	return cache.setResolvedType(key, resolved), nil
+}
+
+//convertPackageType converts only the declaration of the type with the provided name from the package
+func convertPackageType(pkg *parserPackage, name string, depth int) (*resolvedType, error) {
+	typeSpec, ok := pkg.TypeSpecs[name]
+	if !ok {
+		return nil, nil
+	}
+	file := findDeclarationFile(pkg, typeSpec)
+	if file == nil {
+		return nil, nil
+	}
+	genDecl := findTypeSpecDecl(*file, typeSpec)
+	if genDecl == nil {
+		return nil, nil
+	}
+	key := typeKey{pkg: pkg, name: name}
+
+	switch typeSpec.Type.(type) {
+	case *ast.StructType:
+		theStruct, err := convertStructSpecIntoStruct(*file, genDecl, typeSpec)
+		if err != nil {
+			return nil, err
+		}
+		return &resolvedType{key: key, fields: theStruct.Fields, methods: theStruct.Methods, file: *file}, nil
+	case *ast.InterfaceType:
+		theInterface, err := convertInterfaceSpecIntoInterface(*file, genDecl, typeSpec)
+		if err != nil {
+			return nil, err
+		}
+		return &resolvedType{key: key, methods: theInterface.Methods, embeds: theInterface.Embeds,
+			typeSet: theInterface.TypeSet, isInterface: true, file: *file}, nil
+	default:
+		theType, err := convertTypeSpecIntoTypeDecl(*file, genDecl, typeSpec)
+		if err != nil {
+			return nil, err
+		}
+		underlying, err := resolveNamedType(*file, theType.TypeExpr, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if theType.IsAlias {
+			return underlying, nil
+		}
+
+		resolved := &resolvedType{key: key, methods: theType.Methods, file: *file}
+		// a defined type has the fields of the underlying type but not the methods,
+		// except for the interfaces that keep their method set
+		if underlying != nil {
+			resolved.fields = underlying.fields
+			resolved.file = underlying.file
+			if underlying.isInterface {
+				resolved.isInterface = true
+				resolved.methods = underlying.methods
+				resolved.embeds = underlying.embeds
+				resolved.typeSet = underlying.typeSet
+			}
+		}
+		return resolved, nil
+	}
+}
+
+//findDeclarationFile returns the file of the package the node is declared in
+func findDeclarationFile(pkg *parserPackage, node ast.Node) *parserGoFile {
+	for _, file := range pkg.GoFiles {
+		if containsPos(file.AstFile, node.Pos()) {
+			return file
+		}
+	}
+	return nil
+}
+
+//containsPos checks if the position is inside the file
+func containsPos(astFile *ast.File, pos token.Pos) bool {
+	return astFile.Pos() <= pos && pos < astFile.End()
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPromotedFields(t *testing.T) {
+	par := mustParseSource(t, `package p
+
+type (
+	Base struct {
+		ID   int
+		Name string
+	}
+
+	Audit struct {
+		Name    string
+		Created string
+	}
+
+	Deep struct {
+		Level int
+	}
+
+	Middle struct {
+		Deep
+	}
+
+	Entity struct {
+		Base
+		*Audit
+		Middle
+		ID string
+	}
+)
+`)
+
+	entity, err := par.GetStruct("Entity")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "Entity", err)
+	}
+	promoted, err := entity.PromotedFields()
+	if err != nil {
+		t.Fatalf("PromotedFields() error = %v", err)
+	}
+
+	type promotedField struct {
+		name     string
+		path     []string
+		indirect bool
+	}
+	got := []promotedField{}
+	for _, field := range promoted {
+		got = append(got, promotedField{name: field.Name, path: field.Path, indirect: field.Indirect})
+	}
+	// ID is shadowed by the field of Entity and Name is ambiguous between Base and Audit
+	want := []promotedField{
+		{name: "Created", path: []string{"Audit"}, indirect: true},
+		{name: "Deep", path: []string{"Middle"}},
+		{name: "Level", path: []string{"Middle", "Deep"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PromotedFields() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPromotedMethodsGroupedTypes(t *testing.T) {
+	par := mustParseSource(t, groupedMethodSetSource)
+
+	theStruct, err := par.GetStruct("B")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "B", err)
+	}
+	promoted, err := theStruct.PromotedMethods()
+	if err != nil {
+		t.Fatalf("PromotedMethods() error = %v", err)
+	}
+
+	names := []string{}
+	for _, method := range promoted {
+		names = append(names, method.Name)
+	}
+	if want := []string{"Read", "Write"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("PromotedMethods() = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,49 @@
+package parser
+
+//getEnums returns the named types of the package that have constants declared with them,
+//the members of the enum can be declared in any file of the package
+func getEnums(pkg *parserPackage) (enums []*Enum, err error) {
+	enumsByName := map[string]*Enum{}
+	for _, file := range pkg.GoFiles {
+		types, err := getTypes(*file)
+		if err != nil {
+			return nil, err
+		}
+		for _, theType := range types {
+			if theType.IsAlias {
+				continue
+			}
+			enumsByName[theType.Name] = &Enum{
+				PackageName: theType.PackageName,
+				PackagePath: theType.PackagePath,
+				Name:        theType.Name,
+				Type:        theType,
+			}
+		}
+	}
+
+	for _, file := range pkg.GoFiles {
+		consts, err := convertGenDeclsIntoVariable(*file, Const, parseConstDecls(parseGenDeclarations(*file)))
+		if err != nil {
+			return nil, err
+		}
+		for _, theConst := range consts {
+			// the blank constants skip a value of the enum, they are not members
+			if theConst.Name == "_" {
+				continue
+			}
+			if theConst.TypeExpr == nil || theConst.TypeExpr.Kind != NamedTypeExpr || theConst.TypeExpr.Package != "" {
+				continue
+			}
+			enum, ok := enumsByName[theConst.TypeExpr.Name]
+			if !ok {
+				continue
+			}
+			if len(enum.Members) == 0 {
+				enums = append(enums, enum)
+			}
+			enum.Members = append(enum.Members, theConst)
+		}
+	}
+	return enums, nil
+}
@@ -0,0 +1,27 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+//ErrNotFound is matched by the errors returned from the lookups that didn't find any declaration,
+//errors.Is(err, ErrNotFound)
+var ErrNotFound = errors.New("not found")
+
+//NotFoundError is returned from the lookups that didn't find any declaration
+type NotFoundError struct {
+	// Kind of the declaration that was looked up
+	Kind GolangType
+	// Name the qualified name that was looked up
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Kind, e.Name)
+}
+
+//Is makes the error match ErrNotFound
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
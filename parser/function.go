@@ -7,7 +7,7 @@ import (
 func getFunctions(file parserGoFile) (functions []*Function, err error) {
 	funcDecls := parseFuncDeclarations(file)
 	astFunctions := parseFunctionDecls(funcDecls)
-	fileFunctions, err := convertFunctionDeclsIntoFunction(astFunctions)
+	fileFunctions, err := convertFunctionDeclsIntoFunction(file, astFunctions)
 	if err != nil {
 		return nil, err
 	}
@@ -15,15 +15,14 @@ func getFunctions(file parserGoFile) (functions []*Function, err error) {
 	return functions, nil
 }
 
-//parseFunctionDeclsByName returns the first gen decl that contains the provided name
-//if none is found returns nil
-func parseFunctionDeclsByName(name string, funcDecls []*ast.FuncDecl) *ast.FuncDecl {
+//parseFunctionDeclsByName returns the function declarations with the provided name
+func parseFunctionDeclsByName(name string, funcDecls []*ast.FuncDecl) (funcs []*ast.FuncDecl) {
 	for _, funcDecl := range funcDecls {
 		if funcDecl.Recv == nil && funcDecl.Name.Name == name {
-			return funcDecl
+			funcs = append(funcs, funcDecl)
 		}
 	}
-	return nil
+	return funcs
 }
 
 //parseFunctionDecls returns only function declarations from the provided declarations
@@ -36,28 +35,38 @@ func parseFunctionDecls(funcDecls []*ast.FuncDecl) (funcs []*ast.FuncDecl) {
 	return funcs
 }
 
-func convertFunctionDeclsIntoFunction(funcDecls []*ast.FuncDecl) (functions []*Function, err error) {
+func convertFunctionDeclsIntoFunction(file parserGoFile, funcDecls []*ast.FuncDecl) (functions []*Function, err error) {
 	for _, funcDecl := range funcDecls {
 		theFunc := &Function{}
+		theFunc.PackageName = getPackageName(file)
+		theFunc.PackagePath = getPackagePath(file)
 		theFunc.Name = funcDecl.Name.Name
+		theFunc.Pos = parsePosition(file, funcDecl.Pos())
+		theFunc.End = parsePosition(file, funcDecl.End())
+
+		theFunc.TypeParams, err = parseTypeParams(file, funcDecl.Type.TypeParams)
+		if err != nil {
+			return nil, err
+		}
 
-		results, err := parseResults(funcDecl.Type)
+		results, err := parseResults(file, funcDecl.Type)
 		if err != nil {
 			return nil, err
 		}
 		theFunc.Results = results
-		params, err := parseParameters(funcDecl.Type)
+		params, err := parseParameters(file, funcDecl.Type)
 		if err != nil {
 			return nil, err
 		}
 		theFunc.Params = params
 
 		// get comments
-		commentGroup, err := parseComments(funcDecl)
+		commentGroup, err := parseComments(file, funcDecl)
 		if err != nil {
 			return nil, err
 		}
 		theFunc.Doc = commentGroup
+		theFunc.Directives = parseDirectives(file, funcDecl.Doc)
 		functions = append(functions, theFunc)
 	}
 	return functions, nil
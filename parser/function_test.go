@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFunctionParams(t *testing.T) {
+	par := mustParseSource(t, `package p
+
+import "io"
+
+func Copy(dst, src io.Writer, sizes ...int) (n int64, err error) { return 0, nil }
+
+func Unnamed(int, *string, ...byte) error { return nil }
+
+func Blank(_ int, b []string) {}
+
+func Empty() {}
+`)
+
+	//param the name, type and variadic flag of a parameter or result
+	type param struct {
+		name     string
+		typeName string
+		pointer  bool
+		variadic bool
+	}
+	tests := []struct {
+		name        string
+		wantParams  []param
+		wantResults []param
+	}{
+		{
+			name: "Copy",
+			wantParams: []param{
+				{name: "dst", typeName: "io.Writer"},
+				{name: "src", typeName: "io.Writer"},
+				{name: "sizes", typeName: "...int", variadic: true},
+			},
+			wantResults: []param{{name: "n", typeName: "int64"}, {name: "err", typeName: "error"}},
+		},
+		{
+			name: "Unnamed",
+			wantParams: []param{
+				{typeName: "int"},
+				{typeName: "*string", pointer: true},
+				{typeName: "...byte", variadic: true},
+			},
+			wantResults: []param{{typeName: "error"}},
+		},
+		{
+			name:       "Blank",
+			wantParams: []param{{name: "_", typeName: "int"}, {name: "b", typeName: "[]string"}},
+		},
+		{name: "Empty"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			function, err := par.GetFunction(test.name)
+			if err != nil {
+				t.Fatalf("GetFunction(%q) error = %v", test.name, err)
+			}
+			params := []param{}
+			for _, functionParam := range function.Params {
+				params = append(params, param{name: functionParam.Name, typeName: functionParam.Type,
+					pointer: functionParam.IsTypePointer, variadic: functionParam.Variadic})
+			}
+			results := []param{}
+			for _, functionResult := range function.Results {
+				results = append(results, param{name: functionResult.Name, typeName: functionResult.Type,
+					pointer: functionResult.IsTypePointer})
+			}
+			if test.wantParams == nil {
+				test.wantParams = []param{}
+			}
+			if test.wantResults == nil {
+				test.wantResults = []param{}
+			}
+			if !reflect.DeepEqual(params, test.wantParams) {
+				t.Errorf("GetFunction(%q).Params = %+v, want %+v", test.name, params, test.wantParams)
+			}
+			if !reflect.DeepEqual(results, test.wantResults) {
+				t.Errorf("GetFunction(%q).Results = %+v, want %+v", test.name, results, test.wantResults)
+			}
+		})
+	}
+}
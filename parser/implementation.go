@@ -0,0 +1,238 @@
+package parser
+
+import (
+	"go/ast"
+	"strings"
+)
+
+//implementsInterface checks if the method set has all the methods of the interface with identical signatures
+func implementsInterface(methodSet []*Method, interfaceMethods []*Method) bool {
+	signatures := map[string]string{}
+	for _, method := range methodSet {
+		signatures[method.Name] = methodSignature(method)
+	}
+
+	for _, interfaceMethod := range interfaceMethods {
+		signature, ok := signatures[interfaceMethod.Name]
+		if !ok || signature != methodSignature(interfaceMethod) {
+			return false
+		}
+	}
+	return true
+}
+
+//methodSignature returns the signature of the method with the parameter names removed and the named types
+//qualified with the import path of their package, so the signatures from different packages can be compared
+func methodSignature(method *Method) string {
+	params := []string{}
+	for _, param := range method.Params {
+		params = append(params, qualifiedTypeString(method.file, param.TypeExpr))
+	}
+	results := []string{}
+	for _, result := range method.Results {
+		results = append(results, qualifiedTypeString(method.file, result.TypeExpr))
+	}
+	return "(" + strings.Join(params, ", ") + ") (" + strings.Join(results, ", ") + ")"
+}
+
+//qualifiedTypeString returns the type with the named types qualified with the import path of their package
+func qualifiedTypeString(file *parserGoFile, typeExpr *TypeExpr) string {
+	if typeExpr == nil {
+		return ""
+	}
+	if file == nil {
+		return typeExpr.String()
+	}
+	return qualifyTypeExpr(*file, typeExpr).String()
+}
+
+//qualifyTypeExpr returns a copy of the type with the package qualifiers replaced with the import paths,
+//the types declared in the package of the file get the import path of the package and the predeclared
+//aliases are replaced with the types they alias
+func qualifyTypeExpr(file parserGoFile, typeExpr *TypeExpr) *TypeExpr {
+	if typeExpr == nil {
+		return nil
+	}
+
+	qualified := *typeExpr
+	if qualified.Kind == NamedTypeExpr {
+		if qualified.ImportPath != "" {
+			qualified.Package = qualified.ImportPath
+		} else if declaresType(file.Package, qualified.Name) {
+			qualified.Package = packageIdentity(file.Package)
+		} else if qualified.Package == "" {
+			qualified = canonicalPredeclaredType(qualified)
+		}
+	}
+
+	qualified.Elem = qualifyTypeExpr(file, typeExpr.Elem)
+	qualified.Key = qualifyTypeExpr(file, typeExpr.Key)
+	qualified.TypeArgs = qualifyTypeExprs(file, typeExpr.TypeArgs)
+	qualified.Embeds = qualifyTypeExprs(file, typeExpr.Embeds)
+	qualified.Terms = qualifyTypeExprs(file, typeExpr.Terms)
+	if typeExpr.Func != nil {
+		qualified.Func = &FuncSignature{}
+		for _, param := range typeExpr.Func.Params {
+			qualifiedParam := *param
+			qualifiedParam.Name = ""
+			qualifiedParam.TypeExpr = qualifyTypeExpr(file, param.TypeExpr)
+			qualifiedParam.Type = qualifiedParam.TypeExpr.String()
+			qualified.Func.Params = append(qualified.Func.Params, &qualifiedParam)
+		}
+		for _, result := range typeExpr.Func.Results {
+			qualifiedResult := *result
+			qualifiedResult.Name = ""
+			qualifiedResult.TypeExpr = qualifyTypeExpr(file, result.TypeExpr)
+			qualifiedResult.Type = qualifiedResult.TypeExpr.String()
+			qualified.Func.Results = append(qualified.Func.Results, &qualifiedResult)
+		}
+	}
+	if typeExpr.Fields != nil {
+		qualified.Fields = nil
+		for _, field := range typeExpr.Fields {
+			qualifiedField := *field
+			qualifiedField.TypeExpr = qualifyTypeExpr(file, field.TypeExpr)
+			qualifiedField.Type = qualifiedField.TypeExpr.String()
+			qualified.Fields = append(qualified.Fields, &qualifiedField)
+		}
+	}
+	return &qualified
+}
+
+//canonicalPredeclaredType replaces the predeclared aliases with the types they alias, byte with uint8,
+//rune with int32 and any with interface{}, so the same type written differently has the same signature
+func canonicalPredeclaredType(typeExpr TypeExpr) TypeExpr {
+	switch typeExpr.Name {
+	case "byte":
+		typeExpr.Name = "uint8"
+	case "rune":
+		typeExpr.Name = "int32"
+	case "any":
+		return TypeExpr{Kind: InterfaceTypeExpr, Tilde: typeExpr.Tilde}
+	}
+	return typeExpr
+}
+
+func qualifyTypeExprs(file parserGoFile, typeExprs []*TypeExpr) (qualified []*TypeExpr) {
+	for _, typeExpr := range typeExprs {
+		qualified = append(qualified, qualifyTypeExpr(file, typeExpr))
+	}
+	return qualified
+}
+
+//packageIdentity returns the import path of the package, or the directory path for the packages without a module
+func packageIdentity(pkg *parserPackage) string {
+	if pkg.ImportPath != "" {
+		return pkg.ImportPath
+	}
+	return pkg.DirectoryPath
+}
+
+//packageTypeNames returns the names of the types declared in the package that can have methods,
+//the interfaces and the aliases are skipped, the names are in the order they are declared
+func packageTypeNames(pkg *parserPackage) (names []string) {
+	for _, file := range pkg.GoFiles {
+		for _, genDecl := range parseTypeDecls(parseGenDeclarations(*file)) {
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if _, ok := typeSpec.Type.(*ast.InterfaceType); ok || typeSpec.Assign.IsValid() {
+					continue
+				}
+				names = append(names, typeSpec.Name.Name)
+			}
+		}
+	}
+	return names
+}
+
+//typeImplementation checks if the value or the pointer of the type implements the interface,
+//returns nil if neither of them implement it
+func typeImplementation(theType *resolvedType, theInterface *Interface, interfaceMethods []*Method) (
+	*Implementation, error) {
+
+	pointerMethods, err := resolvedMethodSet(theType, true)
+	if err != nil {
+		return nil, err
+	}
+	if !implementsInterface(pointerMethods, interfaceMethods) {
+		return nil, nil
+	}
+
+	valueMethods, err := resolvedMethodSet(theType, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Implementation{
+		PackageName: getPackageName(*theType.key.pkg.GoFiles[0]),
+		PackagePath: theType.key.pkg.ImportPath,
+		TypeName:    theType.key.name,
+		Interface:   theInterface,
+		Value:       implementsInterface(valueMethods, interfaceMethods),
+		Pointer:     true,
+	}, nil
+}
+
+//isConstraintInterface checks if the interface can only be used as a type parameter constraint,
+//it has type set terms or embeds comparable or another constraint interface
+func isConstraintInterface(iface *Interface) (bool, error) {
+	if iface.file == nil {
+		return len(iface.TypeSet) != 0, nil
+	}
+
+	seen := map[typeKey]bool{{pkg: iface.file.Package, name: iface.Name}: true}
+	return hasConstraintElements(*iface.file, iface.TypeSet, iface.Embeds, seen)
+}
+
+//hasConstraintElements checks the type set terms and the embedded interfaces, the embedded interfaces
+//are checked recursively
+func hasConstraintElements(file parserGoFile, typeSet []*TypeExpr, embeds []*TypeExpr, seen map[typeKey]bool) (
+	bool, error) {
+
+	if len(typeSet) != 0 {
+		return true, nil
+	}
+	for _, embed := range embeds {
+		switch {
+		case embed.Kind == InterfaceTypeExpr && !embed.Tilde:
+			// the elements of an interface literal are not split into embeds and terms
+			isConstraint, err := hasConstraintElements(file, nil, embed.Embeds, seen)
+			if err != nil || isConstraint {
+				return isConstraint, err
+			}
+			continue
+		case embed.Kind != NamedTypeExpr || embed.Tilde:
+			return true, nil
+		case embed.Package == "" && embed.ImportPath == "" && !declaresType(file.Package, embed.Name) &&
+			(embed.Name == "comparable" || isBasicDataType(embed.Name)):
+			return true, nil
+		}
+
+		resolved, err := resolveNamedType(file, embed, 0)
+		if err != nil {
+			return false, err
+		}
+		if resolved == nil || seen[resolved.key] {
+			continue
+		}
+		if !resolved.isInterface {
+			return true, nil
+		}
+		seen[resolved.key] = true
+
+		isConstraint, err := hasConstraintElements(resolved.file, resolved.typeSet, resolved.embeds, seen)
+		if err != nil || isConstraint {
+			return isConstraint, err
+		}
+	}
+	return false, nil
+}
+
+//declaresType checks if the package declares a type with the name
+func declaresType(pkg *parserPackage, name string) bool {
+	if pkg == nil {
+		return false
+	}
+	_, ok := pkg.TypeSpecs[name]
+	return ok
+}
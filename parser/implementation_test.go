@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestImplementersGroupedTypes(t *testing.T) {
+	par := mustParseSource(t, groupedMethodSetSource)
+
+	closer, err := par.GetInterface("Closer")
+	if err != nil {
+		t.Fatalf("GetInterface(%q) error = %v", "Closer", err)
+	}
+	implementations, err := par.Implementers(closer)
+	if err != nil {
+		t.Fatalf("Implementers() error = %v", err)
+	}
+
+	type implementation struct {
+		typeName       string
+		value, pointer bool
+	}
+	got := []implementation{}
+	for _, impl := range implementations {
+		got = append(got, implementation{typeName: impl.TypeName, value: impl.Value, pointer: impl.Pointer})
+	}
+	want := []implementation{
+		{typeName: "B", value: true, pointer: true},
+		{typeName: "Named", value: false, pointer: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Implementers() = %+v, want %+v", got, want)
+	}
+}
+
+func TestConcurrentImplementedBy(t *testing.T) {
+	par := mustParseSource(t, groupedMethodSetSource)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			implementations, err := par.ImplementedBy("Named")
+			if err != nil {
+				t.Errorf("ImplementedBy() error = %v", err)
+				return
+			}
+			if len(implementations) != 1 || implementations[0].Interface.Name != "Closer" {
+				t.Errorf("ImplementedBy() returned %d implementations, want Closer", len(implementations))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestImplementersCanonicalSignatures(t *testing.T) {
+	par := mustParseSource(t, `package p
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type (
+	Ctx interface{ context.Context }
+
+	ByteReadWriter interface {
+		io.ByteReader
+		io.ByteWriter
+	}
+
+	RuneSource interface{ Next() rune }
+
+	Values interface{ Get(key any) any }
+)
+
+type LegacyContext struct{}
+
+func (LegacyContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (LegacyContext) Done() <-chan struct{} { return nil }
+
+func (LegacyContext) Err() error { return nil }
+
+func (LegacyContext) Value(key interface{}) interface{} { return nil }
+
+type Buffer struct{}
+
+func (*Buffer) ReadByte() (uint8, error) { return 0, nil }
+
+func (*Buffer) WriteByte(c uint8) error { return nil }
+
+func (*Buffer) Next() int32 { return 0 }
+
+type Store map[string]interface{}
+
+func (s Store) Get(key interface{}) interface{} { return nil }
+`)
+
+	tests := []struct {
+		interfaceName string
+		want          []string
+	}{
+		{interfaceName: "Ctx", want: []string{"LegacyContext"}},
+		{interfaceName: "ByteReadWriter", want: []string{"Buffer"}},
+		{interfaceName: "RuneSource", want: []string{"Buffer"}},
+		{interfaceName: "Values", want: []string{"Store"}},
+	}
+	for _, test := range tests {
+		theInterface, err := par.GetInterface(test.interfaceName)
+		if err != nil {
+			t.Errorf("GetInterface(%q) error = %v", test.interfaceName, err)
+			continue
+		}
+		implementations, err := par.Implementers(theInterface)
+		if err != nil {
+			t.Errorf("Implementers(%q) error = %v", test.interfaceName, err)
+			continue
+		}
+		got := []string{}
+		for _, implementation := range implementations {
+			got = append(got, implementation.TypeName)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Implementers(%q) = %v, want %v", test.interfaceName, got, test.want)
+		}
+	}
+}
@@ -2,12 +2,13 @@ package parser
 
 import (
 	"go/ast"
+	"go/token"
 )
 
 func getImports(file parserGoFile) (imports []*Import) {
 	genDeclarations := parseGenDeclarations(file)
 	genImportDeclarations := parseImportDecls(genDeclarations)
-	fileImports := convertImportDeclsIntoImport(genImportDeclarations)
+	fileImports := convertImportDeclsIntoImport(file, genImportDeclarations)
 	imports = append(imports, fileImports...)
 	return imports
 }
@@ -16,14 +17,12 @@ func parseImportDecls(genDeclarations []*ast.GenDecl) (imports []*ast.GenDecl) {
 	genDeclsWithImportSpec := []*ast.GenDecl{}
 	//loop over all general declarations in the file
 	for _, genDeclaration := range genDeclarations {
-		genDeclarationSpec := genDeclaration.Specs[0]
-		switch genDeclarationSpec.(type) {
-		case *ast.ImportSpec:
+		switch genDeclaration.Tok {
+		case token.IMPORT:
 			genDeclsWithImportSpec = append(genDeclsWithImportSpec, genDeclaration)
 		default:
 			continue
 		}
-
 	}
 
 	return genDeclsWithImportSpec
@@ -32,42 +31,31 @@ func parseImportDecls(genDeclarations []*ast.GenDecl) (imports []*ast.GenDecl) {
 func parseImportsByPackage(file parserGoFile) (imports []*Import) {
 	genDeclarations := parseGenDeclarations(file)
 	genImportDeclarations := parseImportDecls(genDeclarations)
-	fileImports := convertImportDeclsIntoImport(genImportDeclarations)
+	fileImports := convertImportDeclsIntoImport(file, genImportDeclarations)
 	imports = append(imports, fileImports...)
 
 	return imports
 }
 
-func convertImportDeclsIntoImport(genImportDecls []*ast.GenDecl) (imports []*Import) {
+func convertImportDeclsIntoImport(file parserGoFile, genImportDecls []*ast.GenDecl) (imports []*Import) {
 	for _, genImportDecl := range genImportDecls {
 		for _, spec := range genImportDecl.Specs {
 			theImport := &Import{}
 			importSpec := spec.(*ast.ImportSpec)
+			theImport.PackageName = getPackageName(file)
+			theImport.Pos, theImport.End = parseSpecRange(file, genImportDecl, importSpec)
 
-			// PATH & NAME
-			path := importSpec.Path
+			// NAME
 			name := importSpec.Name
 
 			// DOC
 			if importSpec.Doc != nil {
-				theImport.Doc = &CommentGroup{}
-				theImport.Doc.Comments = []*Comment{}
-				for _, docComment := range importSpec.Doc.List {
-					theImport.Doc.Comments = append(theImport.Doc.Comments, &Comment{
-						Text: docComment.Text,
-					})
-				}
+				theImport.Doc = convertCommentGroup(file, importSpec.Doc)
 			}
 
 			// COMMENT
 			if importSpec.Comment != nil {
-				theImport.Comment = &CommentGroup{}
-				theImport.Comment.Comments = []*Comment{}
-				for _, comment := range importSpec.Comment.List {
-					theImport.Comment.Comments = append(theImport.Comment.Comments, &Comment{
-						Text: comment.Text,
-					})
-				}
+				theImport.Comment = convertCommentGroup(file, importSpec.Comment)
 			}
 
 			// if the name is provided set it if not
@@ -78,7 +66,7 @@ func convertImportDeclsIntoImport(genImportDecls []*ast.GenDecl) (imports []*Imp
 				theImport.Name = &name.Name
 			}
 
-			theImport.Path = path.Value
+			theImport.LocalName, theImport.Path = importName(file, importSpec)
 
 			imports = append(imports, theImport)
 		}
@@ -7,7 +7,7 @@ import (
 func getInterfaces(file parserGoFile) (interfaces []*Interface, err error) {
 	genDeclarations := parseGenDeclarations(file)
 	genInterfaceDeclarations := parseInterfaceDecls(genDeclarations)
-	fileInterfaces, err := convertInterfaceDeclsIntoInterface(genInterfaceDeclarations)
+	fileInterfaces, err := convertInterfaceDeclsIntoInterface(file, genInterfaceDeclarations)
 	if err != nil {
 		return nil, err
 	}
@@ -15,33 +15,10 @@ func getInterfaces(file parserGoFile) (interfaces []*Interface, err error) {
 	return interfaces, nil
 }
 
-func parseInterfaceDeclsByName(name string, genDecls []*ast.GenDecl) *ast.GenDecl {
-	//loop over all general declarations in the file
-	for _, genDeclaration := range genDecls {
-		genDeclarationSpec := genDeclaration.Specs[0]
-
-		switch genDeclarationSpec.(type) {
-		case *ast.TypeSpec:
-			genDeclarationType := genDeclarationSpec.(*ast.TypeSpec).Type
-			switch genDeclarationType.(type) {
-			case *ast.InterfaceType:
-				if genDeclarationSpec.(*ast.TypeSpec).Name.Name == name {
-					return genDeclaration
-				}
-			default:
-				continue
-			}
-		default:
-			continue
-		}
-	}
-	return nil
-}
-
 func parseInterfaceByPackage(file parserGoFile) (interfaces []*Interface, err error) {
 	genDeclarations := parseGenDeclarations(file)
 	genInterfaceDeclarations := parseInterfaceDecls(genDeclarations)
-	fileInterfaces, err := convertInterfaceDeclsIntoInterface(genInterfaceDeclarations)
+	fileInterfaces, err := convertInterfaceDeclsIntoInterface(file, genInterfaceDeclarations)
 	if err != nil {
 		return nil, err
 	}
@@ -50,30 +27,27 @@ func parseInterfaceByPackage(file parserGoFile) (interfaces []*Interface, err er
 	return interfaces, nil
 }
 
-// Takes in general declarations and returns only the general declarations with type interface
+// Takes in general declarations and returns only the general declarations with at least one interface type spec
 func parseInterfaceDecls(genDeclarations []*ast.GenDecl) (interfaces []*ast.GenDecl) {
 	genDeclsWithInterfaceType := []*ast.GenDecl{}
 	//loop over all general declarations in the file
-	for _, genDeclaration := range genDeclarations {
-		genDeclarationSpec := genDeclaration.Specs[0]
-
-		switch genDeclarationSpec.(type) {
-		case *ast.TypeSpec:
-			genDeclarationType := genDeclarationSpec.(*ast.TypeSpec).Type
-			switch genDeclarationType.(type) {
-			case *ast.InterfaceType:
+	for _, genDeclaration := range parseTypeDecls(genDeclarations) {
+		for _, spec := range genDeclaration.Specs {
+			if isInterfaceTypeSpec(spec.(*ast.TypeSpec)) {
 				genDeclsWithInterfaceType = append(genDeclsWithInterfaceType, genDeclaration)
-			default:
-				continue
+				break
 			}
-		default:
-			continue
 		}
-
 	}
 	return genDeclsWithInterfaceType
 }
 
+//isInterfaceTypeSpec checks if the type spec declares an interface type
+func isInterfaceTypeSpec(typeSpec *ast.TypeSpec) bool {
+	_, ok := typeSpec.Type.(*ast.InterfaceType)
+	return ok
+}
+
 func parseInterfaceMethodName(astField *ast.Field) string {
 	if astField.Names == nil {
 		return ""
@@ -82,39 +56,211 @@ func parseInterfaceMethodName(astField *ast.Field) string {
 }
 
 // takes in ast interfaces and returns this libraries representation of interface
-func convertInterfaceDeclsIntoInterface(genInterfaceDecls []*ast.GenDecl) (
+func convertInterfaceDeclsIntoInterface(file parserGoFile, genInterfaceDecls []*ast.GenDecl) (
 	interfaces []*Interface, err error) {
 
 	for _, genInterfaceDecl := range genInterfaceDecls {
-		theInterface := &Interface{}
-		genDeclSpec := genInterfaceDecl.Specs[0].(*ast.TypeSpec)
-		theInterface.Name = genDeclSpec.Name.Name
-
-		for _, method := range genDeclSpec.Type.(*ast.InterfaceType).Methods.List {
-			interfaceMethod := &Method{}
+		for _, spec := range genInterfaceDecl.Specs {
+			genDeclSpec := spec.(*ast.TypeSpec)
+			if !isInterfaceTypeSpec(genDeclSpec) {
+				continue
+			}
 
-			params, err := parseParameters(method.Type.(*ast.FuncType))
+			theInterface, err := convertInterfaceSpecIntoInterface(file, genInterfaceDecl, genDeclSpec)
 			if err != nil {
 				return nil, err
 			}
-			results, err := parseResults(method.Type.(*ast.FuncType))
-			if err != nil {
-				return nil, err
+			interfaces = append(interfaces, theInterface)
+		}
+	}
+	return interfaces, nil
+}
+
+//convertInterfaceSpecIntoInterface converts a single interface type spec of the general declaration
+func convertInterfaceSpecIntoInterface(file parserGoFile, genInterfaceDecl *ast.GenDecl, genDeclSpec *ast.TypeSpec) (
+	theInterface *Interface, err error) {
+
+	theInterface = &Interface{}
+	theInterface.PackageName = getPackageName(file)
+	theInterface.PackagePath = getPackagePath(file)
+	theInterface.Name = genDeclSpec.Name.Name
+	theInterface.Pos, theInterface.End = parseSpecRange(file, genInterfaceDecl, genDeclSpec)
+	theInterface.TypeParams, err = parseTypeParams(file, genDeclSpec.TypeParams)
+	if err != nil {
+		return nil, err
+	}
+
+	methods, embeds, err := convertInterfaceMethodList(file, genDeclSpec.Type.(*ast.InterfaceType).Methods)
+	if err != nil {
+		return nil, err
+	}
+	theInterface.Methods = methods
+	for _, embed := range embeds {
+		if isTypeSetTerm(file, embed) {
+			theInterface.TypeSet = append(theInterface.TypeSet, embed)
+		} else {
+			theInterface.Embeds = append(theInterface.Embeds, embed)
+		}
+	}
+	theInterface.file = &file
+	theInterface.Doc, theInterface.Directives = parseTypeSpecDoc(file, genInterfaceDecl, genDeclSpec)
+	return theInterface, nil
+}
+
+//isTypeSetTerm checks if the embedded element of an interface is a type set term (~int, int | string, []byte, User)
+//and not an embedded interface, the named types that can't be found are treated as embedded interfaces
+func isTypeSetTerm(file parserGoFile, embed *TypeExpr) bool {
+	if embed.Tilde {
+		return true
+	}
+	switch embed.Kind {
+	case NamedTypeExpr:
+		if embed.Package == "" && embed.ImportPath == "" && isBasicDataType(embed.Name) {
+			return true
+		}
+		return isDeclaredNonInterface(file, embed)
+	case InterfaceTypeExpr:
+		return false
+	default:
+		return true
+	}
+}
+
+//isDeclaredNonInterface checks if the named type is declared in the parsed or the well known packages
+//as a type that is not an interface, only the type declarations are followed so the interfaces are not converted
+func isDeclaredNonInterface(file parserGoFile, typeExpr *TypeExpr) bool {
+	pkg := file.Package
+	if typeExpr.ImportPath != "" {
+		pkg = findPackageByImportPath(file, typeExpr.ImportPath)
+		if pkg == nil {
+			pkg = wellKnownPackage(typeExpr.ImportPath)
+		}
+	} else if typeExpr.Package != "" {
+		return false
+	}
+	if pkg == nil {
+		return false
+	}
+
+	name := typeExpr.Name
+	for depth := 0; depth <= maxTypeResolutionDepth; depth++ {
+		typeSpec, ok := pkg.TypeSpecs[name]
+		if !ok {
+			return false
+		}
+		switch specType := typeSpec.Type.(type) {
+		case *ast.InterfaceType, *ast.SelectorExpr:
+			// the types from the other packages are not followed
+			return false
+		case *ast.Ident:
+			if isBasicDataType(specType.Name) {
+				return true
 			}
+			name = specType.Name
+		case *ast.IndexExpr, *ast.IndexListExpr:
+			// the instantiated generic types are not followed
+			return false
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+//MethodSet returns all the methods of the interface, the methods of the embedded interfaces are flattened into it.
+//The embedded interfaces are resolved from the parsed packages and from the well known standard library
+//interfaces (error, fmt.Stringer, io.Reader...), the ones that can't be resolved are skipped
+func (i Interface) MethodSet() (methods []*Method, err error) {
+	if i.file == nil {
+		return i.Methods, nil
+	}
 
-			interfaceMethod.Params = append(interfaceMethod.Params, params...)
-			interfaceMethod.Results = append(interfaceMethod.Results, results...)
-			interfaceMethod.Name = parseInterfaceMethodName(method)
-			theInterface.Methods = append(theInterface.Methods, interfaceMethod)
+	seen := map[typeKey]bool{{pkg: i.file.Package, name: i.Name}: true}
+	return interfaceMethodSet(*i.file, i.Methods, i.Embeds, seen)
+}
+
+//interfaceMethodSet flattens the embedded interfaces into the methods, the same method can be
+//embedded multiple times so only the first one is kept
+func interfaceMethodSet(file parserGoFile, methods []*Method, embeds []*TypeExpr, seen map[typeKey]bool) (
+	methodSet []*Method, err error) {
+
+	names := map[string]bool{}
+	for _, method := range methods {
+		if !names[method.Name] {
+			names[method.Name] = true
+			methodSet = append(methodSet, method)
 		}
+	}
 
-		commentGroup, err := parseComments(genInterfaceDecl)
+	for _, embed := range embeds {
+		resolved, err := resolveNamedType(file, embed, 0)
 		if err != nil {
 			return nil, err
 		}
+		// only the embedded interfaces bring their methods, the other named types are type set terms
+		if resolved == nil || !resolved.isInterface || seen[resolved.key] {
+			continue
+		}
+		seen[resolved.key] = true
 
-		theInterface.Doc = commentGroup
-		interfaces = append(interfaces, theInterface)
+		embeddedMethods, err := interfaceMethodSet(resolved.file, resolved.methods, resolved.embeds, seen)
+		if err != nil {
+			return nil, err
+		}
+		for _, method := range embeddedMethods {
+			if !names[method.Name] {
+				names[method.Name] = true
+				methodSet = append(methodSet, method)
+			}
+		}
 	}
-	return interfaces, nil
+	return methodSet, nil
+}
+
+//convertInterfaceMethodList converts the interface method list into this libraries representation of methods,
+//the elements that are not methods (embedded interfaces and type sets) are returned as embeds
+func convertInterfaceMethodList(file parserGoFile, methodList *ast.FieldList) (methods []*Method, embeds []*TypeExpr, err error) {
+	if methodList == nil {
+		return methods, embeds, nil
+	}
+
+	for _, method := range methodList.List {
+		funcType, ok := method.Type.(*ast.FuncType)
+		if !ok {
+			embed, err := convertExpressionIntoTypeExpr(file, method.Type)
+			if err != nil {
+				return nil, nil, err
+			}
+			embeds = append(embeds, embed)
+			continue
+		}
+
+		interfaceMethod := &Method{}
+
+		params, err := parseParameters(file, funcType)
+		if err != nil {
+			return nil, nil, err
+		}
+		results, err := parseResults(file, funcType)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		interfaceMethod.Params = append(interfaceMethod.Params, params...)
+		interfaceMethod.Results = append(interfaceMethod.Results, results...)
+		interfaceMethod.PackageName = getPackageName(file)
+		interfaceMethod.file = &file
+		interfaceMethod.Directives = parseDirectives(file, method.Doc, method.Comment)
+		if method.Doc != nil {
+			interfaceMethod.Doc = convertCommentGroup(file, method.Doc)
+		}
+		if method.Comment != nil {
+			interfaceMethod.Comment = convertCommentGroup(file, method.Comment)
+		}
+		interfaceMethod.Name = parseInterfaceMethodName(method)
+		interfaceMethod.Pos = parsePosition(file, method.Pos())
+		interfaceMethod.End = parsePosition(file, method.End())
+		methods = append(methods, interfaceMethod)
+	}
+	return methods, embeds, nil
 }
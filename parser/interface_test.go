@@ -0,0 +1,217 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+const constraintsSource = `package p
+
+import "io"
+
+type User struct{}
+
+func (User) Read(p []byte) (int, error) { return 0, nil }
+
+func (User) Close() error { return nil }
+
+type ID int
+
+type Reader = io.Reader
+
+type (
+	Cons interface{ User }
+
+	Number interface{ ~int | ~float64 }
+
+	IDs interface{ ID }
+
+	ReadCloser interface {
+		io.Reader
+		Close() error
+	}
+
+	AliasReader interface{ Reader }
+
+	Comparable interface {
+		comparable
+		Close() error
+	}
+
+	Nested interface {
+		Cons
+		Close() error
+	}
+)
+`
+
+func TestInterfaceTypeSetTerms(t *testing.T) {
+	par := mustParseSource(t, constraintsSource)
+
+	tests := []struct {
+		name          string
+		wantEmbeds    []string
+		wantTypeSet   []string
+		wantMethodSet []string
+	}{
+		{name: "Cons", wantTypeSet: []string{"User"}},
+		{name: "Number", wantTypeSet: []string{"~int | ~float64"}},
+		{name: "IDs", wantTypeSet: []string{"ID"}},
+		{name: "ReadCloser", wantEmbeds: []string{"io.Reader"}, wantMethodSet: []string{"Close", "Read"}},
+		{name: "AliasReader", wantEmbeds: []string{"Reader"}, wantMethodSet: []string{"Read"}},
+		{name: "Comparable", wantEmbeds: []string{"comparable"}, wantMethodSet: []string{"Close"}},
+		{name: "Nested", wantEmbeds: []string{"Cons"}, wantMethodSet: []string{"Close"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			theInterface, err := par.GetInterface(test.name)
+			if err != nil {
+				t.Fatalf("GetInterface(%q) error = %v", test.name, err)
+			}
+			if got := typeExprStrings(theInterface.Embeds); !reflect.DeepEqual(got, test.wantEmbeds) {
+				t.Errorf("Embeds = %v, want %v", got, test.wantEmbeds)
+			}
+			if got := typeExprStrings(theInterface.TypeSet); !reflect.DeepEqual(got, test.wantTypeSet) {
+				t.Errorf("TypeSet = %v, want %v", got, test.wantTypeSet)
+			}
+			methodSet, err := theInterface.MethodSet()
+			if err != nil {
+				t.Fatalf("MethodSet() error = %v", err)
+			}
+			if got := methodNames(methodSet); !reflect.DeepEqual(got, test.wantMethodSet) {
+				t.Errorf("MethodSet() = %v, want %v", got, test.wantMethodSet)
+			}
+		})
+	}
+}
+
+//typeExprStrings renders the type expressions, nil if there are none
+func typeExprStrings(typeExprs []*TypeExpr) (strings []string) {
+	for _, typeExpr := range typeExprs {
+		strings = append(strings, typeExpr.String())
+	}
+	return strings
+}
+
+func TestImplementersSkipConstraintInterfaces(t *testing.T) {
+	par := mustParseSource(t, constraintsSource)
+
+	tests := []struct {
+		name     string
+		wantUser bool
+	}{
+		{name: "Cons"},
+		{name: "Number"},
+		{name: "Comparable"},
+		{name: "Nested"},
+		{name: "ReadCloser", wantUser: true},
+		{name: "AliasReader", wantUser: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			theInterface, err := par.GetInterface(test.name)
+			if err != nil {
+				t.Fatalf("GetInterface(%q) error = %v", test.name, err)
+			}
+			implementations, err := par.Implementers(theInterface)
+			if err != nil {
+				t.Fatalf("Implementers() error = %v", err)
+			}
+			gotUser := false
+			for _, implementation := range implementations {
+				gotUser = gotUser || implementation.TypeName == "User"
+			}
+			if gotUser != test.wantUser {
+				t.Errorf("Implementers() includes User = %t, want %t", gotUser, test.wantUser)
+			}
+		})
+	}
+
+	implementations, err := par.ImplementedBy("User")
+	if err != nil {
+		t.Fatalf("ImplementedBy(%q) error = %v", "User", err)
+	}
+	interfaces := []string{}
+	for _, implementation := range implementations {
+		interfaces = append(interfaces, implementation.Interface.Name)
+	}
+	if want := []string{"ReadCloser", "AliasReader"}; !reflect.DeepEqual(interfaces, want) {
+		t.Errorf("ImplementedBy(%q) = %v, want %v", "User", interfaces, want)
+	}
+}
+
+func TestInterfaceStringRoundTrip(t *testing.T) {
+	const source = `package p
+
+import (
+	"context"
+	"io"
+)
+
+type Store interface {
+	io.Closer
+	context.Context
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(key, value string, opts ...int) (n int, err error)
+	Keys() []string
+}
+
+type Number interface {
+	~int | ~float64
+}
+`
+	par := mustParseSource(t, source)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "Store",
+			want: "type Store interface { \n" +
+				"  io.Closer\n" +
+				"  context.Context\n" +
+				"  Get(ctx context.Context, key string) ([]byte, error)\n" +
+				"  Put(key, value string, opts ...int) (n int, err error)\n" +
+				"  Keys() []string\n" +
+				"}\n",
+		},
+		{
+			name: "Number",
+			want: "type Number interface { \n" +
+				"  ~int | ~float64\n" +
+				"}\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			theInterface, err := par.GetInterface(test.name)
+			if err != nil {
+				t.Fatalf("GetInterface(%q) error = %v", test.name, err)
+			}
+			got, err := theInterface.String()
+			if err != nil {
+				t.Fatalf("String() error = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("String() = %q, want %q", got, test.want)
+			}
+
+			// the rendered declaration parses back into the same interface
+			rendered := mustParseSource(t, "package p\n\nimport (\n\t\"context\"\n\t\"io\"\n)\n\n"+got)
+			roundTrip, err := rendered.GetInterface(test.name)
+			if err != nil {
+				t.Fatalf("GetInterface(%q) of the rendered declaration error = %v", test.name, err)
+			}
+			if gotEmbeds, want := typeExprStrings(roundTrip.Embeds), typeExprStrings(theInterface.Embeds); !reflect.DeepEqual(gotEmbeds, want) {
+				t.Errorf("round trip Embeds = %v, want %v", gotEmbeds, want)
+			}
+			if gotTerms, want := typeExprStrings(roundTrip.TypeSet), typeExprStrings(theInterface.TypeSet); !reflect.DeepEqual(gotTerms, want) {
+				t.Errorf("round trip TypeSet = %v, want %v", gotTerms, want)
+			}
+			if gotMethods, want := methodNames(roundTrip.Methods), methodNames(theInterface.Methods); !reflect.DeepEqual(gotMethods, want) {
+				t.Errorf("round trip Methods = %v, want %v", gotMethods, want)
+			}
+		})
+	}
+}
@@ -14,51 +14,146 @@ func parseMethodDecls(funcDecls []*ast.FuncDecl) (funcs []*ast.FuncDecl) {
 	return funcs
 }
 
-func convertFunctionDeclsIntoMethod(funcDecls []*ast.FuncDecl) (methods []*Method, err error) {
+//parseMethodDeclsByReceiver returns only the method declarations whose receiver base type has the provided name
+func parseMethodDeclsByReceiver(typeName string, funcDecls []*ast.FuncDecl) (methods []*ast.FuncDecl) {
 	for _, funcDecl := range funcDecls {
+		if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+			continue
+		}
+		if receiverBaseTypeName(funcDecl.Recv.List[0].Type) == typeName {
+			methods = append(methods, funcDecl)
+		}
+	}
+	return methods
+}
+
+//indexPackageMethodDecls indexes the method declarations of all the files of the package by the name
+//of their receiver base type, the index is built once when the package is grouped
+func indexPackageMethodDecls(pkg *parserPackage) {
+	pkg.MethodDeclsByReceiver = map[string][]*ast.FuncDecl{}
+	for _, funcDecl := range parseMethodDecls(parsePackageFuncDeclarations(pkg)) {
+		if len(funcDecl.Recv.List) == 0 {
+			continue
+		}
+		receiverName := receiverBaseTypeName(funcDecl.Recv.List[0].Type)
+		pkg.MethodDeclsByReceiver[receiverName] = append(pkg.MethodDeclsByReceiver[receiverName], funcDecl)
+	}
+}
+
+//packageMethodDecls returns the method declarations of the type from all the files of the package of the file
+func packageMethodDecls(file parserGoFile, typeName string) []*ast.FuncDecl {
+	if file.Package == nil || file.Package.MethodDeclsByReceiver == nil {
+		return parseMethodDeclsByReceiver(typeName, parseMethodDecls(parseFuncDeclarations(file)))
+	}
+	return file.Package.MethodDeclsByReceiver[typeName]
+}
+
+//receiverBaseTypeName returns the name of the type the receiver belongs to,
+//without the pointer and the type parameters, List for func (l *List[T])
+func receiverBaseTypeName(expression ast.Expr) string {
+	switch expression := expression.(type) {
+	case *ast.Ident:
+		return expression.Name
+	case *ast.StarExpr:
+		return receiverBaseTypeName(expression.X)
+	case *ast.ParenExpr:
+		return receiverBaseTypeName(expression.X)
+	case *ast.IndexExpr:
+		return receiverBaseTypeName(expression.X)
+	case *ast.IndexListExpr:
+		return receiverBaseTypeName(expression.X)
+	default:
+		return ""
+	}
+}
+
+//parseReceiverTypeParams returns the type parameters of a generic receiver, without the constraints
+//since they are only declared on the receiver type
+func parseReceiverTypeParams(expression ast.Expr) (typeParams []*TypeParam) {
+	var indices []ast.Expr
+	switch expression := expression.(type) {
+	case *ast.StarExpr:
+		return parseReceiverTypeParams(expression.X)
+	case *ast.ParenExpr:
+		return parseReceiverTypeParams(expression.X)
+	case *ast.IndexExpr:
+		indices = []ast.Expr{expression.Index}
+	case *ast.IndexListExpr:
+		indices = expression.Indices
+	}
+
+	for _, index := range indices {
+		if ident, ok := index.(*ast.Ident); ok {
+			typeParams = append(typeParams, &TypeParam{Name: ident.Name})
+		}
+	}
+	return typeParams
+}
+
+//attachReceiverTypeParamConstraints sets the constraints of the methods type parameters
+//from the type parameters of the type the methods belong to, they are matched by position
+func attachReceiverTypeParamConstraints(methods []*Method, typeParams []*TypeParam) {
+	for _, method := range methods {
+		for i, methodTypeParam := range method.TypeParams {
+			if i < len(typeParams) {
+				methodTypeParam.Constraint = typeParams[i].Constraint
+			}
+		}
+	}
+}
+
+func convertFunctionDeclsIntoMethod(file parserGoFile, funcDecls []*ast.FuncDecl) (methods []*Method, err error) {
+	for _, funcDecl := range funcDecls {
+		// the methods can be declared in any file of the package
+		file := file
+		if file.Package != nil {
+			if declarationFile := findDeclarationFile(file.Package, funcDecl); declarationFile != nil {
+				file = *declarationFile
+			}
+		}
+
 		theMethod := &Method{}
+		theMethod.file = &file
 		receiver := Receiver{}
+		theMethod.PackageName = getPackageName(file)
 		theMethod.Name = funcDecl.Name.Name
+		theMethod.Pos = parsePosition(file, funcDecl.Pos())
+		theMethod.End = parsePosition(file, funcDecl.End())
 
 		// get parameters
-		for _, astParam := range funcDecl.Type.Params.List {
-			param := &Parameter{}
-			param.Name = astParam.Names[0].Name
-			fieldType, err := convertFieldTypeToString(astParam)
-			if err != nil {
-				return nil, err
-			}
-			param.Type = fieldType
-
-			theMethod.Params = append(theMethod.Params, param)
+		theMethod.Params, err = parseParameters(file, funcDecl.Type)
+		if err != nil {
+			return nil, err
 		}
 
-		receiver.Name = funcDecl.Recv.List[0].Names[0].Name
+		astReceiver := funcDecl.Recv.List[0]
+		//receivers can be unnamed
+		if astReceiver.Names != nil {
+			receiver.Name = astReceiver.Names[0].Name
+		}
 
-		receiver.PointerReceiver = isPointer(funcDecl.Recv.List[0].Type)
-		recvType, err := convertFieldTypeToString(funcDecl.Recv.List[0])
+		receiver.PointerReceiver = isPointer(astReceiver.Type)
+		receiver.Pos = parsePosition(file, astReceiver.Pos())
+		receiver.End = parsePosition(file, astReceiver.End())
+		receiver.BaseType = receiverBaseTypeName(astReceiver.Type)
+		receiver.Type, err = convertTypeExpressionToString(file, astReceiver.Type)
 		if err != nil {
 			return nil, err
 		}
-		receiver.Type = recvType
+		theMethod.Receiver = &receiver
+		theMethod.TypeParams = parseReceiverTypeParams(astReceiver.Type)
 		//get results
-		for _, astResult := range funcDecl.Type.Results.List {
-			result := &Result{}
-			result.Name = astResult.Names[0].Name
-			fieldType, err := convertFieldTypeToString(astResult)
-			if err != nil {
-				return nil, err
-			}
-			result.Type = fieldType
-
-			theMethod.Results = append(theMethod.Results, result)
+		theMethod.Results, err = parseResults(file, funcDecl.Type)
+		if err != nil {
+			return nil, err
 		}
 		// get comments
-		commentGroup, err := parseComments(funcDecl)
+		commentGroup, err := parseComments(file, funcDecl)
 		if err != nil {
 			return nil, err
 		}
 		theMethod.Doc = commentGroup
+		theMethod.Directives = parseDirectives(file, funcDecl.Doc)
 		methods = append(methods, theMethod)
 	}
 	return methods, nil
@@ -0,0 +1,49 @@
+package parser
+
+//resolvedMethodSet returns the methods that can be called on the value or on the pointer of the type,
+//the methods with the pointer receiver are only in the method set of the pointer, unless they are promoted
+//through an embedded pointer. The method sets are cached per parser
+func resolvedMethodSet(theType *resolvedType, pointer bool) (methods []*Method, err error) {
+	key := methodSetKey{key: theType.key, pointer: pointer}
+	cache := packageCache(theType.key.pkg)
+	if methods, ok := cache.methodSet(key); ok {
+		return methods, nil
+	}
+
+	methods, err = computeMethodSet(theType, pointer)
+	if err != nil {
+		return nil, err
+	}
+	cache.setMethodSet(key, methods)
+	return methods, nil
+}
+
+//computeMethodSet collects the declared and the promoted methods of the method set
+func computeMethodSet(theType *resolvedType, pointer bool) (methods []*Method, err error) {
+	if theType.isInterface {
+		// the pointer to an interface doesn't have any methods
+		if pointer {
+			return nil, nil
+		}
+		return interfaceMethodSet(theType.file, theType.methods, theType.embeds, map[typeKey]bool{theType.key: true})
+	}
+
+	for _, method := range theType.methods {
+		if pointer || !method.Receiver.PointerReceiver {
+			methods = append(methods, method)
+		}
+	}
+
+	_, promotedMethods, err := promotedMembers(theType)
+	if err != nil {
+		return nil, err
+	}
+	for _, promotedMethod := range promotedMethods {
+		// the methods promoted from the embedded interfaces don't have a receiver
+		receiver := promotedMethod.Receiver
+		if pointer || promotedMethod.Indirect || receiver == nil || !receiver.PointerReceiver {
+			methods = append(methods, promotedMethod.Method)
+		}
+	}
+	return methods, nil
+}
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+const groupedMethodSetSource = `package p
+
+type (
+	A struct{}
+
+	B struct {
+		A
+	}
+
+	Named int
+
+	Closer interface {
+		Close() error
+	}
+)
+
+func (A) Read() {}
+
+func (*A) Write() {}
+
+func (B) Close() error { return nil }
+
+func (*Named) Close() error { return nil }
+`
+
+func TestMethodSetGroupedTypes(t *testing.T) {
+	par := mustParseSource(t, groupedMethodSetSource)
+
+	tests := []struct {
+		typeName string
+		pointer  bool
+		want     []string
+	}{
+		{typeName: "A", pointer: false, want: []string{"Read"}},
+		{typeName: "A", pointer: true, want: []string{"Read", "Write"}},
+		{typeName: "B", pointer: false, want: []string{"Close", "Read"}},
+		{typeName: "B", pointer: true, want: []string{"Close", "Read", "Write"}},
+		{typeName: "Named", pointer: false, want: nil},
+		{typeName: "Named", pointer: true, want: []string{"Close"}},
+	}
+	for _, test := range tests {
+		methods, err := par.MethodSet(test.typeName, test.pointer)
+		if err != nil {
+			t.Errorf("MethodSet(%q, %t) error = %v", test.typeName, test.pointer, err)
+			continue
+		}
+		if got := methodNames(methods); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("MethodSet(%q, %t) = %v, want %v", test.typeName, test.pointer, got, test.want)
+		}
+	}
+}
+
+func TestConcurrentMethodSet(t *testing.T) {
+	par := mustParseSource(t, groupedMethodSetSource)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			methods, err := par.MethodSet("B", true)
+			if err != nil {
+				t.Errorf("MethodSet() error = %v", err)
+				return
+			}
+			if got := methodNames(methods); !reflect.DeepEqual(got, []string{"Close", "Read", "Write"}) {
+				t.Errorf("MethodSet() = %v", got)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"path"
+	"strings"
+)
+
+//ParserOptions controls which directories and files the parser loads
+type ParserOptions struct {
+	// Recursive parses the packages inside all the sub directories of the root directory
+	Recursive bool
+	// Include globs, when provided only the files whose name or relative path
+	// matches one of the globs are parsed
+	Include []string
+	// Exclude globs, files and directories whose name or relative path
+	// matches one of the globs are skipped
+	Exclude []string
+	// IncludeTests parses the _test.go files as well
+	IncludeTests bool
+	// IncludeSpecialDirectories walks the vendor, testdata and hidden
+	// (starting with "." or "_") directories as well
+	IncludeSpecialDirectories bool
+	// TypeCheck type checks the parsed packages with go/types and sets the resolved types,
+	// the imported packages that are not parsed are type checked from their source
+	TypeCheck bool
+	// DirectiveSyntaxes the syntaxes the directives and the annotations are parsed with,
+	// DefaultDirectiveSyntaxes are used when nil
+	DirectiveSyntaxes []DirectiveSyntax
+	// BuildContext selects only the files built for the target platform and tags,
+	// all the files are parsed when nil
+	BuildContext *BuildContext
+}
+
+//DefaultParserOptions returns the options that parse all the packages
+//under the root directory, without test files and special directories
+func DefaultParserOptions() ParserOptions {
+	return ParserOptions{
+		Recursive: true,
+	}
+}
+
+//validate checks that all the provided globs are valid
+func (o ParserOptions) validate() error {
+	for _, globs := range [][]string{o.Include, o.Exclude} {
+		for _, glob := range globs {
+			if _, err := path.Match(glob, ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//shouldParseFile checks if the file with the provided name and path relative to the root should be parsed
+func (o ParserOptions) shouldParseFile(name string, relativePath string) bool {
+	if !isGoFile(name) {
+		return false
+	}
+	if !o.IncludeTests && strings.HasSuffix(name, "_test.go") {
+		return false
+	}
+	if matchesAnyGlob(o.Exclude, name, relativePath) {
+		return false
+	}
+	if len(o.Include) != 0 && !matchesAnyGlob(o.Include, name, relativePath) {
+		return false
+	}
+	return true
+}
+
+//shouldWalkDirectory checks if the directory with the provided name and path relative to the root should be walked
+func (o ParserOptions) shouldWalkDirectory(name string, relativePath string) bool {
+	if !o.Recursive {
+		return false
+	}
+	if !o.IncludeSpecialDirectories && isSpecialDirectory(name) {
+		return false
+	}
+	return !matchesAnyGlob(o.Exclude, name, relativePath)
+}
+
+//isSpecialDirectory checks if the directory is ignored by the go tool
+func isSpecialDirectory(name string) bool {
+	return name == "vendor" || name == "testdata" ||
+		strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+//matchesAnyGlob checks if the name or the relative path matches any of the provided globs
+func matchesAnyGlob(globs []string, name string, relativePath string) bool {
+	for _, glob := range globs {
+		if matched, _ := path.Match(glob, name); matched {
+			return true
+		}
+		if matched, _ := path.Match(glob, relativePath); matched {
+			return true
+		}
+	}
+	return false
+}
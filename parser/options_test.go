@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+//writeFiles writes the files with the paths relative to the directory, creating the sub directories
+func writeFiles(t *testing.T, directory string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		filePath := filepath.Join(directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParserOptionsFiles(t *testing.T) {
+	directory := t.TempDir()
+	writeFiles(t, directory, map[string]string{
+		"a.go":                  "package a\n",
+		"a_test.go":             "package a\n",
+		"gen.pb.go":             "package a\n",
+		"notes.txt":             "not go",
+		"sub/b.go":              "package b\n",
+		"sub/deep/c.go":         "package c\n",
+		"vendor/v/v.go":         "package v\n",
+		"testdata/td.go":        "package td\n",
+		".hidden/h.go":          "package h\n",
+		"_ignored/i.go":         "package i\n",
+		"internal/mock/mock.go": "package mock\n",
+	})
+
+	tests := []struct {
+		name    string
+		options ParserOptions
+		want    []string
+	}{
+		{
+			name:    "not recursive",
+			options: ParserOptions{},
+			want:    []string{"a.go", "gen.pb.go"},
+		},
+		{
+			name:    "recursive",
+			options: DefaultParserOptions(),
+			want:    []string{"a.go", "gen.pb.go", "internal/mock/mock.go", "sub/b.go", "sub/deep/c.go"},
+		},
+		{
+			name:    "tests",
+			options: ParserOptions{IncludeTests: true},
+			want:    []string{"a.go", "a_test.go", "gen.pb.go"},
+		},
+		{
+			name:    "exclude file name",
+			options: ParserOptions{Recursive: true, Exclude: []string{"*.pb.go"}},
+			want:    []string{"a.go", "internal/mock/mock.go", "sub/b.go", "sub/deep/c.go"},
+		},
+		{
+			name:    "exclude directory path",
+			options: ParserOptions{Recursive: true, Exclude: []string{"sub/deep", "internal"}},
+			want:    []string{"a.go", "gen.pb.go", "sub/b.go"},
+		},
+		{
+			name:    "include relative path",
+			options: ParserOptions{Recursive: true, Include: []string{"sub/*.go", "a.go"}},
+			want:    []string{"a.go", "sub/b.go"},
+		},
+		{
+			name:    "special directories",
+			options: ParserOptions{Recursive: true, IncludeSpecialDirectories: true, Include: []string{"*/*.go"}},
+			want:    []string{".hidden/h.go", "_ignored/i.go", "sub/b.go", "testdata/td.go"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsedFiles, _, err := NewParser(directory, test.options)
+			if err != nil {
+				t.Fatalf("NewParser() error = %v", err)
+			}
+			got := []string{}
+			for _, parsedFile := range parsedFiles {
+				relativePath, err := filepath.Rel(directory, parsedFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, filepath.ToSlash(relativePath))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("NewParser() parsed files = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParserOptionsInvalidGlob(t *testing.T) {
+	_, _, err := NewParser(t.TempDir(), ParserOptions{Exclude: []string{"["}})
+	if err == nil {
+		t.Error("NewParser() with an invalid glob error = nil, want an error")
+	}
+}
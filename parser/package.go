@@ -1,9 +1,175 @@
 package parser
 
+import (
+	"go/ast"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
 func getPackageName(file parserGoFile) string {
 	return file.AstFile.Name.Name
 }
 
+//getPackagePath returns the import path of the package the file belongs to
+func getPackagePath(file parserGoFile) string {
+	if file.Package == nil {
+		return ""
+	}
+	return file.Package.ImportPath
+}
+
+//parseQualifiedName splits the qualified name into the package qualifier and the name,
+//the qualifier can be the package import path or the package name, github.com/x/y/store.User or store.User
+func parseQualifiedName(qualifiedName string) (qualifier string, name string) {
+	index := strings.LastIndex(qualifiedName, ".")
+	if index == -1 {
+		return "", qualifiedName
+	}
+	return qualifiedName[:index], qualifiedName[index+1:]
+}
+
+//getGoFilesByQualifier returns the go files of the packages that match the qualifier,
+//all the go files are returned if the qualifier is empty
+func getGoFilesByQualifier(packages []*parserPackage, qualifier string) (goFiles []parserGoFile) {
+	return getAllGoFilesFromAllPackages(getPackagesByQualifier(packages, qualifier))
+}
+
+//findPackageByImportPath returns the parsed package with the import path, nil if the package is not parsed
+func findPackageByImportPath(file parserGoFile, importPath string) *parserPackage {
+	if importPath == "" || file.Package == nil || file.Package.Parser == nil {
+		return nil
+	}
+
+	for _, pkg := range file.Package.Parser.packages {
+		if pkg.ImportPath == importPath {
+			return pkg
+		}
+	}
+	return nil
+}
+
+//resolveImportPath returns the import path the qualifier refers to inside the file, empty if there is no such import
+func resolveImportPath(file parserGoFile, qualifier string) string {
+	for _, importSpec := range file.AstFile.Imports {
+		name, importPath := importName(file, importSpec)
+		if name == qualifier {
+			return importPath
+		}
+	}
+	return ""
+}
+
+//importName returns the name the import is referred to with inside the file and the unquoted import path,
+//the imports without a name get the name of the parsed package or the name assumed from the import path
+func importName(file parserGoFile, importSpec *ast.ImportSpec) (name string, importPath string) {
+	importPath, err := strconv.Unquote(importSpec.Path.Value)
+	if err != nil {
+		importPath = importSpec.Path.Value
+	}
+
+	if importSpec.Name != nil {
+		return importSpec.Name.Name, importPath
+	}
+	// the package name doesn't have to match the last element of the import path
+	if pkg := findPackageByImportPath(file, importPath); pkg != nil {
+		return getPackageName(*pkg.GoFiles[0]), importPath
+	}
+	return assumedPackageName(importPath), importPath
+}
+
+//assumedPackageName returns the package name assumed from the import path the same way goimports does,
+//the major version suffixes, the go- prefix and everything after the first non identifier character are dropped,
+//yaml for gopkg.in/yaml.v3, bar for github.com/x/go-bar/v2
+func assumedPackageName(importPath string) string {
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil && path.Dir(importPath) != "." {
+			base = path.Base(path.Dir(importPath))
+		}
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if index := strings.IndexFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); index != -1 {
+		base = base[:index]
+	}
+	return base
+}
+
+//isDeclaredInPackage checks if the type name is declared in the package of the file or it's a predeclared type
+func isDeclaredInPackage(file parserGoFile, name string) bool {
+	if isBasicDataType(name) || name == "error" || name == "any" || name == "comparable" {
+		return true
+	}
+	if file.Package == nil {
+		return false
+	}
+	_, ok := file.Package.TypeSpecs[name]
+	return ok
+}
+
+//resolveDotImportPath returns the import path of the dot import the unqualified type name comes from,
+//empty if the file doesn't have dot imports or the name is not found in any of them. The packages that are
+//not parsed are assumed to declare the name, it's ambiguous if more than one of them could declare it
+func resolveDotImportPath(file parserGoFile, ident *ast.Ident) (importPath string, ambiguous bool) {
+	dotImportPaths := []string{}
+	for _, importSpec := range file.AstFile.Imports {
+		if name, importPath := importName(file, importSpec); name == "." {
+			dotImportPaths = append(dotImportPaths, importPath)
+		}
+	}
+	if len(dotImportPaths) == 0 {
+		return "", false
+	}
+
+	// the type checked packages know exactly where the name comes from
+	if file.Package != nil && file.Package.TypesInfo != nil {
+		if named, ok := file.Package.TypesInfo.TypeOf(ident).(*types.Named); ok && named.Obj().Pkg() != nil {
+			if importPath := named.Obj().Pkg().Path(); importPath != packageIdentity(file.Package) {
+				return importPath, false
+			}
+			return "", false
+		}
+	}
+
+	unknownImportPaths := []string{}
+	for _, importPath := range dotImportPaths {
+		pkg := findPackageByImportPath(file, importPath)
+		if pkg == nil {
+			pkg = wellKnownPackage(importPath)
+		}
+		if pkg == nil {
+			unknownImportPaths = append(unknownImportPaths, importPath)
+			continue
+		}
+		if _, ok := pkg.TypeSpecs[ident.Name]; ok {
+			return importPath, false
+		}
+	}
+	switch len(unknownImportPaths) {
+	case 0:
+		return "", false
+	case 1:
+		return unknownImportPaths[0], false
+	default:
+		return "", true
+	}
+}
+
+//getPackagesByQualifier returns the packages with the import path or the package name, an empty qualifier matches all the packages
+func getPackagesByQualifier(packages []*parserPackage, qualifier string) (qualifiedPackages []*parserPackage) {
+	for _, pkg := range packages {
+		if qualifier != "" && qualifier != pkg.ImportPath && qualifier != getPackageName(*pkg.GoFiles[0]) {
+			continue
+		}
+		qualifiedPackages = append(qualifiedPackages, pkg)
+	}
+	return qualifiedPackages
+}
+
 func getAllGoFilesFromAllPackages(packages []*parserPackage) (goFiles []parserGoFile) {
 	for _, pkg := range packages {
 		for _, file := range pkg.GoFiles {
@@ -12,3 +178,86 @@ func getAllGoFilesFromAllPackages(packages []*parserPackage) (goFiles []parserGo
 	}
 	return goFiles
 }
+
+//parsePackageFuncDeclarations returns the function declarations from all the files of the package
+func parsePackageFuncDeclarations(pkg *parserPackage) (funcDecls []*ast.FuncDecl) {
+	for _, file := range pkg.GoFiles {
+		funcDecls = append(funcDecls, parseFuncDeclarations(*file)...)
+	}
+	return funcDecls
+}
+
+//setPackageImportPaths sets the import path of the packages from the module path of the nearest go.mod file,
+//lookupDirectory converts the package directory into a slash separated path the go.mod files are searched from,
+//readGoMod reads the go.mod file inside the provided directory
+func setPackageImportPaths(packages []*parserPackage, lookupDirectory func(directoryPath string) string,
+	readGoMod func(directory string) ([]byte, error)) {
+
+	for _, pkg := range packages {
+		directory := lookupDirectory(pkg.DirectoryPath)
+		modulePath, moduleDirectory, ok := findModule(directory, readGoMod)
+		if !ok {
+			continue
+		}
+		pkg.ImportPath = joinImportPath(modulePath, moduleDirectory, directory)
+		// external test packages get the _test suffix the same way the go tool names them
+		if strings.HasSuffix(getPackageName(*pkg.GoFiles[0]), "_test") {
+			pkg.ImportPath += "_test"
+		}
+	}
+}
+
+//findModule walks up from the directory until it finds a go.mod file with a module path
+func findModule(directory string, readGoMod func(directory string) ([]byte, error)) (
+	modulePath string, moduleDirectory string, ok bool) {
+
+	for {
+		content, err := readGoMod(directory)
+		if err == nil {
+			modulePath = parseModulePath(content)
+			if modulePath != "" {
+				return modulePath, directory, true
+			}
+		}
+
+		// the parent of .. is not ., the directories above the sources have no known go.mod files
+		parentDirectory := path.Dir(directory)
+		if parentDirectory == directory || path.Base(directory) == ".." {
+			return "", "", false
+		}
+		directory = parentDirectory
+	}
+}
+
+//parseModulePath returns the path from the module directive of the go.mod file
+func parseModulePath(goMod []byte) string {
+	for _, line := range strings.Split(string(goMod), "\n") {
+		line = strings.TrimSpace(line)
+		if index := strings.Index(line, "//"); index != -1 {
+			line = strings.TrimSpace(line[:index])
+		}
+		// the module keyword is separated from the path by whitespace, modulex is not the module directive
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+
+		modulePath := fields[1]
+		if unquotedModulePath, err := strconv.Unquote(modulePath); err == nil {
+			modulePath = unquotedModulePath
+		}
+		return modulePath
+	}
+	return ""
+}
+
+//joinImportPath joins the module path with the directory relative to the module directory
+func joinImportPath(modulePath string, moduleDirectory string, directory string) string {
+	if directory == moduleDirectory {
+		return modulePath
+	}
+	if moduleDirectory == "." {
+		return path.Join(modulePath, directory)
+	}
+	return path.Join(modulePath, strings.TrimPrefix(directory, strings.TrimSuffix(moduleDirectory, "/")+"/"))
+}
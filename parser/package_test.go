@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestDotImportPaths(t *testing.T) {
+	tests := []struct {
+		name           string
+		src            string
+		wantImportPath string
+		wantAmbiguous  bool
+	}{
+		{
+			name: "single unparsed dot import",
+			src: `package p
+
+import . "strings"
+
+type T struct{ L Builder }
+`,
+			wantImportPath: "strings",
+		},
+		{
+			name: "well known dot import",
+			src: `package p
+
+import (
+	. "io"
+	. "strings"
+)
+
+type T struct{ L Reader }
+`,
+			wantImportPath: "io",
+		},
+		{
+			name: "multiple unparsed dot imports",
+			src: `package p
+
+import (
+	. "bytes"
+	. "strings"
+)
+
+type T struct{ L Builder }
+`,
+			wantAmbiguous: true,
+		},
+		{
+			name: "declared in the package",
+			src: `package p
+
+import . "strings"
+
+type Builder struct{}
+
+type T struct{ L Builder }
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			par := mustParseSource(t, test.src)
+			theStruct, err := par.GetStruct("T")
+			if err != nil {
+				t.Fatalf("GetStruct(%q) error = %v", "T", err)
+			}
+			typeExpr := theStruct.Fields[0].TypeExpr
+			if typeExpr.ImportPath != test.wantImportPath || typeExpr.AmbiguousImportPath != test.wantAmbiguous {
+				t.Errorf("ImportPath = %q, AmbiguousImportPath = %t, want %q, %t",
+					typeExpr.ImportPath, typeExpr.AmbiguousImportPath, test.wantImportPath, test.wantAmbiguous)
+			}
+		})
+	}
+}
+
+func TestParseModulePath(t *testing.T) {
+	tests := []struct {
+		name  string
+		goMod string
+		want  string
+	}{
+		{name: "plain", goMod: "module example.com/app\n\ngo 1.19\n", want: "example.com/app"},
+		{name: "trailing comment", goMod: "module example.com/app // the app\n", want: "example.com/app"},
+		{name: "comments before", goMod: "// Deprecated: use example.com/v2\n// module example.com/old\nmodule example.com/app\n", want: "example.com/app"},
+		{name: "quoted", goMod: "module \"example.com/app\"\n", want: "example.com/app"},
+		{name: "raw quoted", goMod: "module `example.com/app` // quoted\n", want: "example.com/app"},
+		{name: "tab separated", goMod: "module\texample.com/app\n", want: "example.com/app"},
+		{name: "indented", goMod: "  module example.com/app\r\n", want: "example.com/app"},
+		{name: "module prefix", goMod: "modulex example.com/other\nmodule example.com/app\n", want: "example.com/app"},
+		{name: "no module directive", goMod: "go 1.19\n", want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := parseModulePath([]byte(test.goMod)); got != test.want {
+				t.Errorf("parseModulePath(%q) = %q, want %q", test.goMod, got, test.want)
+			}
+		})
+	}
+}
+
+func TestPackageImportPaths(t *testing.T) {
+	par := mustParseSources(t, map[string]string{
+		"go.mod":                     "module example.com/app\n",
+		"main.go":                    "package main\n",
+		"internal/store/store.go":    "package store\n",
+		"internal/store/store_x.go":  "package store_test\n",
+		"tools/go.mod":               "module example.com/tools // nested\n",
+		"tools/gen/gen.go":           "package gen\n",
+		"tools/gen/gen_test.go":      "package gen\n",
+		"scripts/modulex/go.mod":     "modulex example.com/broken\n",
+		"scripts/modulex/run.go":     "package run\n",
+		"../outside/no_module/nm.go": "package nm\n",
+	})
+
+	tests := []struct {
+		directory      string
+		name           string
+		wantImportPath string
+	}{
+		{directory: ".", name: "main", wantImportPath: "example.com/app"},
+		{directory: "internal/store", name: "store", wantImportPath: "example.com/app/internal/store"},
+		{directory: "internal/store", name: "store_test", wantImportPath: "example.com/app/internal/store_test"},
+		{directory: "tools/gen", name: "gen", wantImportPath: "example.com/tools/gen"},
+		// a go.mod without a module directive is skipped and the parent module is used
+		{directory: "scripts/modulex", name: "run", wantImportPath: "example.com/app/scripts/modulex"},
+		{directory: "../outside/no_module", name: "nm", wantImportPath: ""},
+	}
+
+	packages, err := par.GetPackages()
+	if err != nil {
+		t.Fatalf("GetPackages() error = %v", err)
+	}
+	for _, test := range tests {
+		found := false
+		for _, pkg := range packages {
+			if pkg.DirectoryPath != test.directory || pkg.Name != test.name {
+				continue
+			}
+			found = true
+			if pkg.ImportPath != test.wantImportPath {
+				t.Errorf("package %s in %s ImportPath = %q, want %q", test.name, test.directory, pkg.ImportPath, test.wantImportPath)
+			}
+		}
+		if !found {
+			t.Errorf("package %s in %s not found", test.name, test.directory)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package parser
 
 import (
-	"fmt"
-	"go/ast"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
 )
 
 type IParser interface {
@@ -10,51 +13,182 @@ type IParser interface {
 	GetPackages() ([]Package, error)
 	// Get everything inside the package that matches the provided name
 	// GetPackage(packageName string)
+	// The Get lookups return the first occurrence or ErrNotFound and the Find lookups return all the matches,
+	// the names can be qualified with the package import path or the package name, github.com/x/y/store.User or store.User
 	//Interface
 	GetInterfaces() (interfaces []*Interface, err error)
 	GetInterface(name string) (theInterface *Interface, err error)
+	FindInterfaces(name string) (interfaces []*Interface, err error)
 	//Struct
 	GetStruct(structName string) (theStruct *Struct, err error)
 	GetStructs() (structs []*Struct, err error)
+	GetStructsByDirective(name string) (structs []*Struct, err error)
+	FindStructs(structName string) (structs []*Struct, err error)
 	//Function
 	GetFunction(funcName string) (theFunc *Function, err error)
 	GetFunctions() (funcs []*Function, err error)
+	FindFunctions(funcName string) (funcs []*Function, err error)
 	//Variables
-	//GetVariable(variableName string) (theVariable *Variable, err error) //TODO
-	//GetVariablesByNames(...string) (variables []*Variable, err error)   //TODO
+	GetVariable(variableName string) (theVariable *Variable, err error)
+	GetVariablesByNames(variableNames ...string) (variables []*Variable, err error)
+	FindVariables(variableName string) (variables []*Variable, err error)
 	GetVariables() (vars []Variable, err error)
 	GetConstantVariables() (consts []Variable, err error)
 	//Import
 	GetImports() (types []*Import, err error) //TODO
 
-	//GetTypes   //TODO
+	//Types
 	// example:  type Something string
+	GetTypes() (types []*TypeDecl, err error)
+	GetType(name string) (theType *TypeDecl, err error)
+	FindTypes(name string) (types []*TypeDecl, err error)
+	//Enums
+	// example:  type Status int; const ( Active Status = iota; Inactive )
+	GetEnums() (enums []*Enum, err error)
+	GetEnum(name string) (theEnum *Enum, err error)
+	FindEnums(name string) (enums []*Enum, err error)
+	//Method sets
+	// the methods callable on T or on *T, including the methods promoted from the embedded fields
+	MethodSet(typeName string, pointer bool) (methods []*Method, err error)
+	//Implementations
+	Implementers(iface *Interface) (implementations []*Implementation, err error)
+	ImplementedBy(typeName string) (implementations []*Implementation, err error)
 	// parseFiles(directoryName string) ([]*GoFile, error)
 }
 
 //Parser used to parse go files
 type Parser struct {
-	packages []*parserPackage
+	packages          []*parserPackage
+	fset              *token.FileSet
+	directiveSyntaxes []DirectiveSyntax
+	cache             parserCache
 	// files              []*parserGoFile
 }
 
-// parser needs to read one package at a time
+//NewParser parses the provided root, the root can be a single go file or a directory,
+//which directories and files are parsed is controlled by the options.
+//Returns the paths of all the files that were parsed
+func NewParser(root string, options ParserOptions) (parsedFiles []string, parser IParser, err error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if info.IsDir() {
+		return newParser(parserFileSystem{fsys: os.DirFS(root), directory: root}, ".", options)
+	}
+
+	directory := filepath.Dir(root)
+	return newParser(parserFileSystem{fsys: os.DirFS(directory), directory: directory}, filepath.Base(root), options)
+}
+
+//NewParserFS parses the provided root inside the file system, the root can be a single go file
+//or a directory and it must be a valid fs.FS path ("." for the root of the file system).
+//It can be used to parse embed.FS, fstest.MapFS, zip files and other virtual file systems.
+//Returns the paths of all the files that were parsed
+func NewParserFS(fsys fs.FS, root string, options ParserOptions) (parsedFiles []string, parser IParser, err error) {
+	if !fs.ValidPath(root) {
+		return nil, nil, &fs.PathError{Op: "parse", Path: root, Err: fs.ErrInvalid}
+	}
+
+	return newParser(parserFileSystem{fsys: fsys}, root, options)
+}
+
+//ParseSource parses the provided source without reading it from the disk,
+//the file name is only used for reporting and grouping
+func ParseSource(fileName string, src []byte) (parser IParser, err error) {
+	return ParseSources(map[string][]byte{fileName: src})
+}
+
+//ParseSources parses the provided sources without reading them from the disk,
+//the files are grouped into packages by their directory and package clause.
+//The go.mod files in the sources are used to set the import paths of the packages
+func ParseSources(sources map[string][]byte) (parser IParser, err error) {
+	fileNames := make([]string, 0, len(sources))
+	for fileName := range sources {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	par := Parser{}
+	par.fset = token.NewFileSet()
+
+	directories := []string{}
+	goFilesByDirectory := map[string][]*parserGoFile{}
+	goModsByDirectory := map[string][]byte{}
+	for _, fileName := range fileNames {
+		if filepath.Base(fileName) == "go.mod" {
+			goModsByDirectory[filepath.ToSlash(filepath.Dir(fileName))] = sources[fileName]
+			continue
+		}
+
+		goFile, err := parseSource(par.fset, fileName, sources[fileName])
+		if err != nil {
+			return nil, err
+		}
+
+		directory := filepath.Dir(fileName)
+		if _, ok := goFilesByDirectory[directory]; !ok {
+			directories = append(directories, directory)
+		}
+		goFilesByDirectory[directory] = append(goFilesByDirectory[directory], goFile)
+	}
+
+	for _, directory := range directories {
+		par.packages = append(par.packages, groupGoFilesIntoPackages(directory, goFilesByDirectory[directory])...)
+	}
+	setPackageImportPaths(par.packages, filepath.ToSlash, func(directory string) ([]byte, error) {
+		goMod, ok := goModsByDirectory[directory]
+		if !ok {
+			return nil, fs.ErrNotExist
+		}
+		return goMod, nil
+	})
+	par.setPackagesParser()
+
+	return &par, nil
+}
+
+func newParser(pfs parserFileSystem, root string, options ParserOptions) (parsedFiles []string, parser IParser, err error) {
+	err = options.validate()
+	if err != nil {
+		return nil, nil, err
+	}
 
-func NewParser(fileToParse string) (parsedFiles []string, parser IParser, err error) {
 	par := Parser{}
-	par.packages, err = parsePackages(".")
+	par.fset = token.NewFileSet()
+	par.directiveSyntaxes = options.DirectiveSyntaxes
+	par.packages, err = parsePackages(par.fset, pfs, root, options)
 	if err != nil {
-		return parsedFiles, parser, err
+		return nil, nil, err
+	}
+	setPackageImportPaths(par.packages, pfs.moduleLookupDirectory, pfs.readGoMod)
+	par.setPackagesParser()
+	if options.TypeCheck {
+		typeCheckPackages(par.fset, par.packages)
+	}
+
+	for _, goFile := range getAllGoFilesFromAllPackages(par.packages) {
+		parsedFiles = append(parsedFiles, goFile.Path)
 	}
 
 	return parsedFiles, &par, nil
 }
 
+//setPackagesParser links the packages to the parser so the declarations can be resolved across packages
+func (p *Parser) setPackagesParser() {
+	for _, pkg := range p.packages {
+		pkg.Parser = p
+	}
+}
+
 func (p *Parser) GetPackages() (packages []Package, err error) {
 	for _, parserPkg := range p.packages {
 		pkg := Package{}
 		pkg.Name = getPackageName(*parserPkg.GoFiles[0])
 		pkg.DirectoryPath = parserPkg.DirectoryPath
+		pkg.ImportPath = parserPkg.ImportPath
+		pkg.Doc = getPackageDoc(parserPkg)
 
 		for _, parserGoFile := range parserPkg.GoFiles {
 			// STRUCT
@@ -81,15 +215,31 @@ func (p *Parser) GetPackages() (packages []Package, err error) {
 				return nil, err
 			}
 
+			// TYPES
+			types, err := getTypes(*parserGoFile)
+			if err != nil {
+				return nil, err
+			}
+
 			// IMPORTS
 			imports := getImports(*parserGoFile)
+			goos, goarch := parseFileNameTarget(parserGoFile.Name)
 
 			pkg.Files = append(pkg.Files, GoFile{
-				Imports:    imports,
-				Structs:    structs,
-				Variables:  variables,
-				Functions:  functions,
-				Interfaces: interfaces,
+				Name:             parserGoFile.Name,
+				Path:             parserGoFile.Path,
+				BuildConstraint:  parseBuildConstraint(*parserGoFile),
+				GOOS:             goos,
+				GOARCH:           goarch,
+				Directives:       parseFileDirectives(*parserGoFile),
+				HeaderComments:   parseHeaderComments(*parserGoFile),
+				FloatingComments: parseFloatingComments(*parserGoFile),
+				Imports:          imports,
+				Structs:          structs,
+				Variables:        variables,
+				Functions:        functions,
+				Interfaces:       interfaces,
+				Types:            types,
 			})
 		}
 		packages = append(packages, pkg)
@@ -124,58 +274,70 @@ func (p *Parser) GetInterfaces() (interfaces []*Interface, err error) {
 	return interfaces, nil
 }
 
-//GetInterface gets the first occurence of interface that has the provided name,
-//returns nil if the interface does not exist
+//GetInterface gets the first occurrence of the interface with the provided name, the name can be qualified
+//with the package import path or the package name, returns ErrNotFound if the interface does not exist
 func (p *Parser) GetInterface(name string) (theInterface *Interface, err error) {
-	interfaces := []*Interface{}
-	goFiles := getAllGoFilesFromAllPackages(p.packages)
-	for _, goFile := range goFiles {
-		genDeclarations := parseGenDeclarations(goFile)
-		interfaceDecl := parseInterfaceDeclsByName(name, genDeclarations)
-		if interfaceDecl == nil {
-			continue
-		}
-		ids := []*ast.GenDecl{interfaceDecl}
-		fileInterfaces, err := convertInterfaceDeclsIntoInterface(ids)
-		if err != nil {
-			return nil, err
-		}
-		interfaces = append(interfaces, fileInterfaces...)
+	interfaces, err := p.FindInterfaces(name)
+	if err != nil {
+		return nil, err
 	}
-
 	if len(interfaces) == 0 {
-		return nil, nil
+		return nil, &NotFoundError{Kind: INTERFACE, Name: name}
 	}
 	return interfaces[0], nil
 }
 
-//GetStruct gets the first struct that has the provided name, if no struct is found returns nil
-func (p *Parser) GetStruct(structName string) (theStruct *Struct, err error) {
-	structs := []*Struct{}
-	goFiles := getAllGoFilesFromAllPackages(p.packages)
+//FindInterfaces gets all the interfaces with the provided name, the name can be qualified
+//with the package import path or the package name
+func (p *Parser) FindInterfaces(name string) (interfaces []*Interface, err error) {
+	qualifier, name := parseQualifiedName(name)
+	goFiles := getGoFilesByQualifier(p.packages, qualifier)
 	for _, goFile := range goFiles {
-		genDecls := parseGenDeclarations(goFile)
-		fmt.Println("amount: ", len(genDecls))
-		structDecls := parseStructDeclsByName(structName, genDecls)
-		if structDecls == nil {
-			continue
-		}
-
-		ids := []*ast.GenDecl{structDecls}
-		fileStructs, err := convertStructDeclsIntoStruct(goFile, ids)
+		fileInterfaces, err := getInterfaces(goFile)
 		if err != nil {
 			return nil, err
 		}
-		structs = append(structs, fileStructs...)
+		for _, fileInterface := range fileInterfaces {
+			if fileInterface.Name == name {
+				interfaces = append(interfaces, fileInterface)
+			}
+		}
 	}
+	return interfaces, nil
+}
 
+//GetStruct gets the first occurrence of the struct with the provided name, the name can be qualified
+//with the package import path or the package name, returns ErrNotFound if the struct does not exist
+func (p *Parser) GetStruct(structName string) (theStruct *Struct, err error) {
+	structs, err := p.FindStructs(structName)
+	if err != nil {
+		return nil, err
+	}
 	if len(structs) == 0 {
-		return nil, nil
+		return nil, &NotFoundError{Kind: STRUCT, Name: structName}
 	}
-
 	return structs[0], nil
 }
 
+//FindStructs gets all the structs with the provided name, the name can be qualified
+//with the package import path or the package name
+func (p *Parser) FindStructs(structName string) (structs []*Struct, err error) {
+	qualifier, name := parseQualifiedName(structName)
+	goFiles := getGoFilesByQualifier(p.packages, qualifier)
+	for _, goFile := range goFiles {
+		fileStructs, err := getStructs(goFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, fileStruct := range fileStructs {
+			if fileStruct.Name == name {
+				structs = append(structs, fileStruct)
+			}
+		}
+	}
+	return structs, nil
+}
+
 //GetStructs get all the structs
 func (p *Parser) GetStructs() (structs []*Struct, err error) {
 	goFiles := getAllGoFilesFromAllPackages(p.packages)
@@ -190,6 +352,21 @@ func (p *Parser) GetStructs() (structs []*Struct, err error) {
 	return structs, nil
 }
 
+//GetStructsByDirective gets all the structs that have the directive or the annotation with the name,
+//GetStructsByDirective("gen:mock") returns the structs annotated with // +gen:mock
+func (p *Parser) GetStructsByDirective(name string) (structs []*Struct, err error) {
+	allStructs, err := p.GetStructs()
+	if err != nil {
+		return nil, err
+	}
+	for _, theStruct := range allStructs {
+		if hasDirective(theStruct.Directives, name) {
+			structs = append(structs, theStruct)
+		}
+	}
+	return structs, nil
+}
+
 //GetFunctions get all the functions
 func (p *Parser) GetFunctions() (functions []*Function, err error) {
 	goFiles := getAllGoFilesFromAllPackages(p.packages)
@@ -203,21 +380,34 @@ func (p *Parser) GetFunctions() (functions []*Function, err error) {
 	return functions, nil
 }
 
-// GetFunction gets the first occurance of the function with the provided name
+//GetFunction gets the first occurrence of the function with the provided name, the name can be qualified
+//with the package import path or the package name, returns ErrNotFound if the function does not exist
 func (p *Parser) GetFunction(funcName string) (theFunc *Function, err error) {
-	funcs := []*Function{}
-	goFiles := getAllGoFilesFromAllPackages(p.packages)
+	funcs, err := p.FindFunctions(funcName)
+	if err != nil {
+		return nil, err
+	}
+	if len(funcs) == 0 {
+		return nil, &NotFoundError{Kind: FUNCTION, Name: funcName}
+	}
+	return funcs[0], nil
+}
+
+//FindFunctions gets all the functions with the provided name, the name can be qualified
+//with the package import path or the package name
+func (p *Parser) FindFunctions(funcName string) (funcs []*Function, err error) {
+	qualifier, name := parseQualifiedName(funcName)
+	goFiles := getGoFilesByQualifier(p.packages, qualifier)
 	for _, goFile := range goFiles {
 		funcDecls := parseFuncDeclarations(goFile)
-		astFunction := parseFunctionDeclsByName(funcName, funcDecls)
-		ids := []*ast.FuncDecl{astFunction}
-		fileFunctions, err := convertFunctionDeclsIntoFunction(ids)
+		astFunctions := parseFunctionDeclsByName(name, funcDecls)
+		fileFunctions, err := convertFunctionDeclsIntoFunction(goFile, astFunctions)
 		if err != nil {
 			return nil, err
 		}
 		funcs = append(funcs, fileFunctions...)
 	}
-	return funcs[0], nil
+	return funcs, nil
 }
 
 //GetVariables gets all the variables
@@ -235,13 +425,58 @@ func (p *Parser) GetVariables() (variables []Variable, err error) {
 	return variables, nil
 }
 
+//GetVariable gets the first occurrence of the variable or constant with the provided name, the name can be qualified
+//with the package import path or the package name, returns ErrNotFound if the variable does not exist
+func (p *Parser) GetVariable(variableName string) (theVariable *Variable, err error) {
+	variables, err := p.FindVariables(variableName)
+	if err != nil {
+		return nil, err
+	}
+	if len(variables) == 0 {
+		return nil, &NotFoundError{Kind: VARIABLE, Name: variableName}
+	}
+	return variables[0], nil
+}
+
+//GetVariablesByNames gets the first occurrence of every variable or constant with the provided names,
+//in the order of the names, returns ErrNotFound if any of the variables does not exist
+func (p *Parser) GetVariablesByNames(variableNames ...string) (variables []*Variable, err error) {
+	for _, variableName := range variableNames {
+		variable, err := p.GetVariable(variableName)
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, variable)
+	}
+	return variables, nil
+}
+
+//FindVariables gets all the variables and constants with the provided name, the name can be qualified
+//with the package import path or the package name
+func (p *Parser) FindVariables(variableName string) (variables []*Variable, err error) {
+	qualifier, name := parseQualifiedName(variableName)
+	goFiles := getGoFilesByQualifier(p.packages, qualifier)
+	for _, goFile := range goFiles {
+		fileVariables, err := getVariablesAndConstants(goFile)
+		if err != nil {
+			return nil, err
+		}
+		for i := range fileVariables {
+			if fileVariables[i].Name == name {
+				variables = append(variables, &fileVariables[i])
+			}
+		}
+	}
+	return variables, nil
+}
+
 //GetConstantVariables gets all the constant variables
 func (p *Parser) GetConstantVariables() (consts []Variable, err error) {
 	goFiles := getAllGoFilesFromAllPackages(p.packages)
 	for _, goFile := range goFiles {
 		genDecls := parseGenDeclarations(goFile)
 		constVariableGenDecls := parseConstDecls(genDecls)
-		fileConsts, err := convertGenDeclsIntoVariable(0, constVariableGenDecls)
+		fileConsts, err := convertGenDeclsIntoVariable(goFile, Const, constVariableGenDecls)
 		if err != nil {
 			return nil, err
 		}
@@ -249,3 +484,180 @@ func (p *Parser) GetConstantVariables() (consts []Variable, err error) {
 	}
 	return consts, nil
 }
+
+//GetTypes gets all the named types that are not structs or interfaces, and all the type aliases
+func (p *Parser) GetTypes() (types []*TypeDecl, err error) {
+	goFiles := getAllGoFilesFromAllPackages(p.packages)
+	for _, goFile := range goFiles {
+		fileTypes, err := getTypes(goFile)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, fileTypes...)
+	}
+	return types, nil
+}
+
+//GetType gets the first occurrence of the named type with the provided name, the name can be qualified
+//with the package import path or the package name, returns ErrNotFound if the type does not exist
+func (p *Parser) GetType(name string) (theType *TypeDecl, err error) {
+	types, err := p.FindTypes(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(types) == 0 {
+		return nil, &NotFoundError{Kind: TYPE, Name: name}
+	}
+	return types[0], nil
+}
+
+//FindTypes gets all the named types with the provided name, the name can be qualified
+//with the package import path or the package name
+func (p *Parser) FindTypes(name string) (types []*TypeDecl, err error) {
+	qualifier, name := parseQualifiedName(name)
+	goFiles := getGoFilesByQualifier(p.packages, qualifier)
+	for _, goFile := range goFiles {
+		fileTypes, err := getTypes(goFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, fileType := range fileTypes {
+			if fileType.Name == name {
+				types = append(types, fileType)
+			}
+		}
+	}
+	return types, nil
+}
+
+//GetEnums gets all the named types that have constants declared with them, together with the constants
+func (p *Parser) GetEnums() (enums []*Enum, err error) {
+	return p.FindEnums("")
+}
+
+//GetEnum gets the first occurrence of the enum with the provided name, the name can be qualified
+//with the package import path or the package name, returns ErrNotFound if the enum does not exist
+func (p *Parser) GetEnum(name string) (theEnum *Enum, err error) {
+	enums, err := p.FindEnums(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(enums) == 0 {
+		return nil, &NotFoundError{Kind: ENUM, Name: name}
+	}
+	return enums[0], nil
+}
+
+//FindEnums gets all the enums with the provided name, the name can be qualified
+//with the package import path or the package name, an empty name matches all the enums
+func (p *Parser) FindEnums(name string) (enums []*Enum, err error) {
+	qualifier, name := parseQualifiedName(name)
+	for _, pkg := range getPackagesByQualifier(p.packages, qualifier) {
+		packageEnums, err := getEnums(pkg)
+		if err != nil {
+			return nil, err
+		}
+		for _, enum := range packageEnums {
+			if name == "" || enum.Name == name {
+				enums = append(enums, enum)
+			}
+		}
+	}
+	return enums, nil
+}
+
+//MethodSet gets the methods that can be called on the value of the type, or on the pointer to the type if pointer is true,
+//the methods promoted from the embedded fields are included. The name can be qualified with the package import path
+//or the package name, returns ErrNotFound if the type does not exist
+func (p *Parser) MethodSet(typeName string, pointer bool) (methods []*Method, err error) {
+	qualifier, name := parseQualifiedName(typeName)
+	for _, pkg := range getPackagesByQualifier(p.packages, qualifier) {
+		theType, err := lookupPackageType(pkg, name, 0)
+		if err != nil {
+			return nil, err
+		}
+		if theType != nil {
+			return resolvedMethodSet(theType, pointer)
+		}
+	}
+	return nil, &NotFoundError{Kind: TYPE, Name: typeName}
+}
+
+//Implementers gets all the types from all the packages whose value or pointer implements the interface,
+//the methods are matched by the name and the signature
+func (p *Parser) Implementers(iface *Interface) (implementations []*Implementation, err error) {
+	// constraint interfaces can only be used as type parameter constraints
+	isConstraint, err := isConstraintInterface(iface)
+	if err != nil || isConstraint {
+		return nil, err
+	}
+	interfaceMethods, err := iface.MethodSet()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range p.packages {
+		for _, typeName := range packageTypeNames(pkg) {
+			theType, err := lookupPackageType(pkg, typeName, 0)
+			if err != nil {
+				return nil, err
+			}
+			if theType == nil {
+				continue
+			}
+			implementation, err := typeImplementation(theType, iface, interfaceMethods)
+			if err != nil {
+				return nil, err
+			}
+			if implementation != nil {
+				implementations = append(implementations, implementation)
+			}
+		}
+	}
+	return implementations, nil
+}
+
+//ImplementedBy gets all the interfaces from all the packages that the value or the pointer of the type implements,
+//the name can be qualified with the package import path or the package name, returns ErrNotFound if the type does not exist
+func (p *Parser) ImplementedBy(typeName string) (implementations []*Implementation, err error) {
+	qualifier, name := parseQualifiedName(typeName)
+	var theType *resolvedType
+	for _, pkg := range getPackagesByQualifier(p.packages, qualifier) {
+		theType, err = lookupPackageType(pkg, name, 0)
+		if err != nil {
+			return nil, err
+		}
+		if theType != nil {
+			break
+		}
+	}
+	if theType == nil {
+		return nil, &NotFoundError{Kind: TYPE, Name: typeName}
+	}
+
+	interfaces, err := p.GetInterfaces()
+	if err != nil {
+		return nil, err
+	}
+	for _, iface := range interfaces {
+		isConstraint, err := isConstraintInterface(iface)
+		if err != nil {
+			return nil, err
+		}
+		if isConstraint {
+			continue
+		}
+		interfaceMethods, err := iface.MethodSet()
+		if err != nil {
+			return nil, err
+		}
+		implementation, err := typeImplementation(theType, iface, interfaceMethods)
+		if err != nil {
+			return nil, err
+		}
+		if implementation != nil {
+			implementations = append(implementations, implementation)
+		}
+	}
+	return implementations, nil
+}
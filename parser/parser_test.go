@@ -0,0 +1,207 @@
+package parser
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"testing/fstest"
+)
+
+//mustParseSources parses the sources and fails the test if they don't parse
+func mustParseSources(t *testing.T, sources map[string]string) *Parser {
+	t.Helper()
+
+	srcs := map[string][]byte{}
+	for fileName, src := range sources {
+		srcs[fileName] = []byte(src)
+	}
+	parser, err := ParseSources(srcs)
+	if err != nil {
+		t.Fatalf("ParseSources() error = %v", err)
+	}
+	return parser.(*Parser)
+}
+
+//mustParseSource parses a single file of the package p
+func mustParseSource(t *testing.T, src string) *Parser {
+	t.Helper()
+	return mustParseSources(t, map[string]string{"p/p.go": src})
+}
+
+//methodNames returns the names of the methods in order
+func methodNames(methods []*Method) (names []string) {
+	for _, method := range methods {
+		names = append(names, method.Name)
+	}
+	return names
+}
+
+func TestNewParserFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":                  {Data: []byte("module example.com/app\n")},
+		"app/user.go":             {Data: []byte("package app\n\ntype User struct{ Name string }\n")},
+		"app/store/store.go":      {Data: []byte("package store\n\ntype Store interface{ Get() }\n")},
+		"app/store/README.md":     {Data: []byte("# store")},
+		"app/vendor/v/v.go":       {Data: []byte("package v\n")},
+		"app/store/store_test.go": {Data: []byte("package store\n")},
+	}
+
+	tests := []struct {
+		name            string
+		root            string
+		options         ParserOptions
+		wantFiles       []string
+		wantImportPaths []string
+		wantErr         bool
+	}{
+		{
+			name:            "directory",
+			root:            "app",
+			options:         DefaultParserOptions(),
+			wantFiles:       []string{"app/store/store.go", "app/user.go"},
+			wantImportPaths: []string{"example.com/app/app", "example.com/app/app/store"},
+		},
+		{
+			name:            "root of the file system",
+			root:            ".",
+			options:         ParserOptions{Recursive: true, Exclude: []string{"store"}},
+			wantFiles:       []string{"app/user.go"},
+			wantImportPaths: []string{"example.com/app/app"},
+		},
+		{
+			name:            "single file",
+			root:            "app/store/store.go",
+			wantFiles:       []string{"app/store/store.go"},
+			wantImportPaths: []string{"example.com/app/app/store"},
+		},
+		{name: "invalid path", root: "/app", wantErr: true},
+		{name: "missing path", root: "missing", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsedFiles, par, err := NewParserFS(fsys, test.root, test.options)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("NewParserFS(%q) error = nil, want an error", test.root)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewParserFS(%q) error = %v", test.root, err)
+			}
+			sort.Strings(parsedFiles)
+			if !reflect.DeepEqual(parsedFiles, test.wantFiles) {
+				t.Errorf("NewParserFS(%q) parsed files = %v, want %v", test.root, parsedFiles, test.wantFiles)
+			}
+
+			packages, err := par.GetPackages()
+			if err != nil {
+				t.Fatalf("GetPackages() error = %v", err)
+			}
+			importPaths := []string{}
+			for _, pkg := range packages {
+				importPaths = append(importPaths, pkg.ImportPath)
+			}
+			sort.Strings(importPaths)
+			if !reflect.DeepEqual(importPaths, test.wantImportPaths) {
+				t.Errorf("GetPackages() import paths = %v, want %v", importPaths, test.wantImportPaths)
+			}
+		})
+	}
+}
+
+func TestParseSources(t *testing.T) {
+	tests := []struct {
+		name         string
+		sources      map[string]string
+		wantPackages []string
+		wantErr      bool
+	}{
+		{
+			name:         "single file",
+			sources:      map[string]string{"user.go": "package user\n\ntype User struct{}\n"},
+			wantPackages: []string{"user ."},
+		},
+		{
+			name: "grouped by directory",
+			sources: map[string]string{
+				"a/a.go":   "package a\n",
+				"a/b.go":   "package a\n",
+				"b/b.go":   "package b\n",
+				"b/b2.go":  "package b\n",
+				"c/d/d.go": "package d\n",
+			},
+			wantPackages: []string{"a a", "b b", "d c/d"},
+		},
+		{
+			name: "grouped by package clause",
+			sources: map[string]string{
+				"a/a.go":      "package a\n",
+				"a/a_test.go": "package a_test\n",
+			},
+			wantPackages: []string{"a a", "a_test a"},
+		},
+		{
+			name:    "syntax error",
+			sources: map[string]string{"a/a.go": "package a\n\ntype A struct {\n"},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srcs := map[string][]byte{}
+			for fileName, src := range test.sources {
+				srcs[fileName] = []byte(src)
+			}
+			par, err := ParseSources(srcs)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("ParseSources() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSources() error = %v", err)
+			}
+			packages, err := par.GetPackages()
+			if err != nil {
+				t.Fatalf("GetPackages() error = %v", err)
+			}
+			got := []string{}
+			for _, pkg := range packages {
+				got = append(got, pkg.Name+" "+pkg.DirectoryPath)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, test.wantPackages) {
+				t.Errorf("GetPackages() = %v, want %v", got, test.wantPackages)
+			}
+		})
+	}
+}
+
+func TestParseSourceMethodsAcrossFiles(t *testing.T) {
+	par := mustParseSources(t, map[string]string{
+		"p/user.go":    "package p\n\ntype User struct{ Name string }\n",
+		"p/methods.go": "package p\n\nfunc (u User) GetName() string { return u.Name }\n\nfunc (u *User) SetName(name string) { u.Name = name }\n",
+	})
+
+	user, err := par.GetStruct("User")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "User", err)
+	}
+	if got, want := methodNames(user.Methods), []string{"GetName", "SetName"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStruct(%q).Methods = %v, want %v", "User", got, want)
+	}
+
+	single, err := ParseSource("user.go", []byte("package p\n\ntype User struct{ Name string }\n"))
+	if err != nil {
+		t.Fatalf("ParseSource() error = %v", err)
+	}
+	user, err = single.GetStruct("User")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "User", err)
+	}
+	if len(user.Fields) != 1 || user.Fields[0].Name != "Name" {
+		t.Errorf("GetStruct(%q).Fields = %v, want the Name field", "User", user.Fields)
+	}
+}
@@ -2,15 +2,81 @@ package parser
 
 import (
 	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
 	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
 )
 
 // parser package, directory, file are used just for the parser, this should not be used
 // outside of the internals of the library
 type parserPackage struct {
-	GoFiles          []*parserGoFile
-	InnerDirectories []parserDirectory
-	DirectoryPath    string
+	GoFiles       []*parserGoFile
+	DirectoryPath string
+	ImportPath    string
+	// Parser the parser the package belongs to, used to resolve the declarations of the other packages
+	Parser *Parser
+	// Constants the constants of the package by name, blank constants are only in ConstantsByIdent
+	Constants        map[string]*parserConstant
+	ConstantsByIdent map[*ast.Ident]*parserConstant
+	// TypeSpecs the type declarations of the package by name, used to resolve the typed constants
+	TypeSpecs map[string]*ast.TypeSpec
+	// MethodDeclsByReceiver the method declarations of all the files by the name of their receiver base type
+	MethodDeclsByReceiver map[string][]*ast.FuncDecl
+	// TypesInfo the go/types information of the package, nil if the packages are not type checked
+	TypesInfo *types.Info
+}
+
+//parserConstant is a single constant name with the implicit repetition of the const group applied
+type parserConstant struct {
+	Name       string
+	Expression ast.Expr
+	Type       ast.Expr
+	Iota       int
+	value      constant.Value
+	evaluated  bool
+	evaluating bool
+}
+
+//parserFileSystem is the file system the packages are read from, directory is
+//the os directory the file system is rooted at, it's empty for virtual file systems
+type parserFileSystem struct {
+	fsys      fs.FS
+	directory string
+}
+
+//path converts the file system path into the path that is shown to the users of the library
+func (pfs parserFileSystem) path(fsPath string) string {
+	if pfs.directory == "" {
+		return fsPath
+	}
+	return filepath.Join(pfs.directory, filepath.FromSlash(fsPath))
+}
+
+//moduleLookupDirectory converts the package directory path into the slash separated
+//directory path the go.mod files are searched from
+func (pfs parserFileSystem) moduleLookupDirectory(directoryPath string) string {
+	if pfs.directory == "" {
+		return directoryPath
+	}
+
+	// the go.mod file can be above the root of the os file system
+	absoluteDirectoryPath, err := filepath.Abs(directoryPath)
+	if err != nil {
+		return filepath.ToSlash(directoryPath)
+	}
+	return filepath.ToSlash(absoluteDirectoryPath)
+}
+
+//readGoMod reads the go.mod file inside the directory returned by moduleLookupDirectory
+func (pfs parserFileSystem) readGoMod(directory string) ([]byte, error) {
+	if pfs.directory == "" {
+		return fs.ReadFile(pfs.fsys, path.Join(directory, "go.mod"))
+	}
+	return os.ReadFile(filepath.Join(filepath.FromSlash(directory), "go.mod"))
 }
 
 type parserDirectory struct {
@@ -22,4 +88,8 @@ type parserGoFile struct {
 	Name    string
 	Path    string
 	AstFile *ast.File
+	Src     []byte
+	// Fset the file set shared by all the files of the parser
+	Fset    *token.FileSet
+	Package *parserPackage
 }
@@ -2,16 +2,17 @@ package parser
 
 import (
 	"errors"
-	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
 	"io/fs"
-	"os"
+	"path"
+	"path/filepath"
 	"regexp"
+	"strings"
 )
 
-var goFilesRegex = regexp.MustCompile(".*.go")
+var goFilesRegex = regexp.MustCompile(`^.+\.go$`)
 
 //isPointer checks if the methods has a pointer receiver
 func isPointer(expression ast.Expr) bool {
@@ -53,206 +54,281 @@ func parseGenDeclarations(file parserGoFile) []*ast.GenDecl {
 	return genDeclarations
 }
 
-func parsePackages(directoryName string) (packageDirectories []*parserPackage, err error) {
-
-	directoryFiles, err := os.ReadDir(directoryName)
+//parsePackages parses the root of the file system, if the root is a file only that file is parsed,
+//if it's a directory the packages in it are parsed depending on the provided options
+func parsePackages(fset *token.FileSet, pfs parserFileSystem, root string, options ParserOptions) (packages []*parserPackage, err error) {
+	info, err := fs.Stat(pfs.fsys, root)
 	if err != nil {
-		return packageDirectories, err
+		return nil, err
 	}
 
-	// initialize the root package
-	packageDirectory := &parserPackage{}
-	packageDirectory.DirectoryPath = directoryName
-	packageDirectory.InnerDirectories = make([]parserDirectory, 0)
-	packageDirectory.GoFiles = make([]*parserGoFile, 0)
-
-	for _, file := range directoryFiles {
-		// if it's a directory append it to inner directorise of the package
-		// if it's a go file append it to go files of the package
-		if file.IsDir() {
-			packageDirectory.InnerDirectories = append(packageDirectory.InnerDirectories, parserDirectory{
-				DirectoryPath: fmt.Sprintf("%s/%s", directoryName, file.Name()),
-				FsDirectory:   file,
-			})
-		} else {
-			// if it's not a go file skip it, checks the extension .go to determine if it's a go file
-			if !goFilesRegex.MatchString(file.Name()) {
-				continue
-			}
-
-			goFile, err := parseFile(file, file.Name())
-			if err != nil {
-				fmt.Println("the parse go file error")
-				fmt.Println(err)
-				return packageDirectories, err
-			}
-
-			packageDirectory.GoFiles = append(packageDirectory.GoFiles, goFile)
+	if !info.IsDir() {
+		goFile, err := parseFile(fset, pfs, root)
+		if err != nil {
+			return nil, err
 		}
+		return groupGoFilesIntoPackages(pfs.path(path.Dir(root)), []*parserGoFile{goFile}), nil
 	}
 
-	packageDirectories = append(packageDirectories, packageDirectory)
+	directories := []parserDirectory{{DirectoryPath: root}}
+	for len(directories) != 0 {
+		directory := directories[0]
+		directories = directories[1:]
 
-	err = parsePackage(&packageDirectories, packageDirectory)
-	if err != nil {
-		return packageDirectories, err
+		directoryPackages, innerDirectories, err := parsePackage(fset, pfs, root, directory, options)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, directoryPackages...)
+		directories = append(directories, innerDirectories...)
 	}
 
-	return packageDirectories, nil
+	return packages, nil
 }
 
-func parsePackage(allPackageDirectories *[]*parserPackage, parserPackages ...*parserPackage) (err error) {
-	// fmt.Println("print once: ", len(packageDirectories))
-	packageDirectories := make([]*parserPackage, 0)
+//parsePackage parses the go files inside the directory and returns
+//the packages they belong to and the inner directories that should be walked next
+func parsePackage(fset *token.FileSet, pfs parserFileSystem, root string, directory parserDirectory, options ParserOptions) (
+	packages []*parserPackage, innerDirectories []parserDirectory, err error) {
 
-	if len(parserPackages) == 0 {
-		return
+	files, err := fs.ReadDir(pfs.fsys, directory.DirectoryPath)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	for _, parserPkg := range parserPackages {
+	goFiles := []*parserGoFile{}
+	for _, file := range files {
+		filePath := path.Join(directory.DirectoryPath, file.Name())
+		relativePath := relativeFsPath(root, filePath)
 
-		// fmt.Println("inner: ", len(packageDirectory.InnerDirectories))
-		for _, innerDirectory := range parserPkg.InnerDirectories {
-			// fmt.Println("the inner directory: ", innerDirectory.DirectoryPath)
-			// fmt.Println("reading: ", innerDirectory.DirectoryPath)
-			files, err := os.ReadDir(innerDirectory.DirectoryPath)
-			if err != nil {
-				return err
+		if file.IsDir() {
+			if options.shouldWalkDirectory(file.Name(), relativePath) {
+				innerDirectories = append(innerDirectories, parserDirectory{
+					DirectoryPath: filePath,
+					FsDirectory:   file,
+				})
 			}
+			continue
+		}
 
-			pp := new(parserPackage)
-			pp.DirectoryPath = innerDirectory.DirectoryPath
-
-			for _, file := range files {
-				// fmt.Println("the ff filej: ", file.Name())
-				if file.IsDir() {
-					pp.InnerDirectories = append(pp.InnerDirectories, parserDirectory{
-						DirectoryPath: fmt.Sprintf("%s/%s", innerDirectory.DirectoryPath, file.Name()),
-						FsDirectory:   file,
-					})
-					continue
-				}
-
-				// if it's not a go file skip it, checks the extension .go to determine if it's a go file
-				if !goFilesRegex.MatchString(file.Name()) {
-					continue
-				}
-
-				goFile, err := parseFile(file, fmt.Sprintf("%s/%s", innerDirectory.DirectoryPath, file.Name()))
-				if err != nil {
-					fmt.Println("parsing go stuff: ", err)
-					return err
-				}
-
-				pp.GoFiles = append(pp.GoFiles, goFile)
+		if !options.shouldParseFile(file.Name(), relativePath) {
+			continue
+		}
+		if options.BuildContext != nil {
+			matched, err := options.BuildContext.matchFile(pfs.fsys, directory.DirectoryPath, file.Name())
+			if err != nil {
+				return nil, nil, err
 			}
-
-			// package is only a package if it has .go files
-			if len(pp.GoFiles) != 0 {
-				packageDirectories = append(packageDirectories, pp)
+			if !matched {
+				continue
 			}
+		}
 
+		goFile, err := parseFile(fset, pfs, filePath)
+		if err != nil {
+			return nil, nil, err
 		}
+		goFiles = append(goFiles, goFile)
 	}
 
-	*allPackageDirectories = append(*allPackageDirectories, packageDirectories...)
-	err = parsePackage(allPackageDirectories, packageDirectories...)
+	return groupGoFilesIntoPackages(pfs.path(directory.DirectoryPath), goFiles), innerDirectories, nil
+}
+
+//groupGoFilesIntoPackages groups the go files of a directory by their package clause,
+//a package is only a package if it has .go files
+func groupGoFilesIntoPackages(directoryPath string, goFiles []*parserGoFile) (packages []*parserPackage) {
+	packagesByName := map[string]*parserPackage{}
+	for _, goFile := range goFiles {
+		packageName := getPackageName(*goFile)
+		pkg, ok := packagesByName[packageName]
+		if !ok {
+			pkg = &parserPackage{DirectoryPath: directoryPath}
+			packagesByName[packageName] = pkg
+			packages = append(packages, pkg)
+		}
+		goFile.Package = pkg
+		pkg.GoFiles = append(pkg.GoFiles, goFile)
+	}
+
+	for _, pkg := range packages {
+		collectPackageConstants(pkg)
+		indexPackageMethodDecls(pkg)
+	}
+	return packages
+}
+
+//parseFile reads and parses the file with the provided file system path
+func parseFile(fset *token.FileSet, pfs parserFileSystem, filePath string) (goFile *parserGoFile, err error) {
+	src, err := fs.ReadFile(pfs.fsys, filePath)
 	if err != nil {
-		return err
+		return goFile, err
 	}
 
-	return nil
+	return parseSource(fset, pfs.path(filePath), src)
 }
 
-func parseFile(file fs.DirEntry, filePath string) (goFile *parserGoFile, err error) {
-	astFile, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.ParseComments|parser.DeclarationErrors)
+//parseSource parses the provided source into the file set, the file path is only used for reporting
+func parseSource(fset *token.FileSet, filePath string, src []byte) (goFile *parserGoFile, err error) {
+	astFile, err := parser.ParseFile(fset, filePath, src, parser.ParseComments|parser.DeclarationErrors)
 	if err != nil {
 		return goFile, err
 	}
 
 	return &parserGoFile{
 		AstFile: astFile,
+		Src:     src,
+		Fset:    fset,
 		Path:    filePath,
-		Name:    file.Name(),
+		Name:    filepath.Base(filePath),
 	}, nil
 }
 
-//ConvertFieldTypeToString convers the provided field into a string representation
-func convertFieldTypeToString(field *ast.Field) (string, error) {
-	expression := field.Type
-	switch expression.(type) {
-	case *ast.Ident:
-		return identityStringConversion(expression), nil
-	case *ast.ArrayType:
-		return arrayStringConversion(expression), nil
-	case *ast.StarExpr:
-		return starStringConversion(expression), nil
-	case *ast.MapType:
-		return mapStringConversion(expression), nil
-	case *ast.FuncType:
-		theFunc, err := funcStringConversion(expression)
+//relativeFsPath returns the file system path relative to the root
+func relativeFsPath(root string, fsPath string) string {
+	if root == "." {
+		return fsPath
+	}
+	return strings.TrimPrefix(fsPath, root+"/")
+}
+
+//isGoFile checks the extension .go to determine if it's a go file
+func isGoFile(fileName string) bool {
+	return goFilesRegex.MatchString(fileName)
+}
+
+//parsePosition converts the token position into this libraries representation of the position
+func parsePosition(file parserGoFile, pos token.Pos) Position {
+	if !pos.IsValid() {
+		return Position{}
+	}
+
+	position := file.Fset.Position(pos)
+	return Position{
+		Filename: position.Filename,
+		Line:     position.Line,
+		Column:   position.Column,
+		Offset:   position.Offset,
+	}
+}
+
+//parseSpecRange returns the start and the end of the spec, if the declaration has only a single spec
+//without parentheses the range of the whole declaration is returned so it includes the keyword
+func parseSpecRange(file parserGoFile, genDecl *ast.GenDecl, spec ast.Spec) (pos Position, end Position) {
+	if !genDecl.Lparen.IsValid() {
+		return parsePosition(file, genDecl.Pos()), parsePosition(file, genDecl.End())
+	}
+	return parsePosition(file, spec.Pos()), parsePosition(file, spec.End())
+}
+
+//parseTypeParams returns the type parameters from the provided type parameter list,
+//type parameters declared together [K, V any] are split into separate type parameters
+func parseTypeParams(file parserGoFile, typeParamList *ast.FieldList) (typeParams []*TypeParam, err error) {
+	if typeParamList == nil {
+		return typeParams, nil
+	}
+
+	for _, astTypeParam := range typeParamList.List {
+		constraint, err := convertExpressionIntoTypeExpr(file, astTypeParam.Type)
 		if err != nil {
-			return theFunc, err
+			return nil, err
+		}
+		for _, name := range astTypeParam.Names {
+			typeParams = append(typeParams, &TypeParam{
+				Name:       name.Name,
+				Constraint: constraint,
+				Pos:        parsePosition(file, name.Pos()),
+				End:        parsePosition(file, astTypeParam.End()),
+			})
 		}
-		return theFunc, nil
-	default:
-		return "", fmt.Errorf("field type not supported: %v", expression)
 	}
+	return typeParams, nil
 }
 
-//ParseParameters returns the parameters from the provided function
-func parseParameters(astFunc *ast.FuncType) (parameters []*Parameter, err error) {
+//parseParameters returns the parameters from the provided function, the parameters declared
+//together (a, b int) are split into separate parameters
+func parseParameters(file parserGoFile, astFunc *ast.FuncType) (parameters []*Parameter, err error) {
 	funcParams := []*Parameter{}
 	if astFunc.Params == nil {
 		return funcParams, nil
 	}
-	astParams := astFunc.Params.List
-
-	for _, astParam := range astParams {
-		funcParam := &Parameter{}
-		//parse parameter names if there are any names assigned
-		if astParam.Names != nil {
-			funcParam.Name = astParam.Names[0].Name
-		}
 
-		funcParam.Type, err = convertFieldTypeToString(astParam)
+	for group, astParam := range astFunc.Params.List {
+		typeExpr, err := convertExpressionIntoTypeExpr(file, astParam.Type)
 		if err != nil {
 			return funcParams, err
 		}
-		funcParam.IsTypePointer = isPointer(astParam.Type)
-		funcParams = append(funcParams, funcParam)
+		resolvedType, underlyingType := resolveExpressionType(file, astParam.Type)
+
+		for _, name := range parseFieldNames(astParam) {
+			funcParam := &Parameter{
+				IsTypePointer:  isPointer(astParam.Type),
+				Type:           typeExpr.String(),
+				TypeExpr:       typeExpr,
+				ResolvedType:   resolvedType,
+				UnderlyingType: underlyingType,
+				Pos:            parsePosition(file, astParam.Pos()),
+				End:            parsePosition(file, astParam.End()),
+				group:          group + 1,
+			}
+			_, funcParam.Variadic = astParam.Type.(*ast.Ellipsis)
+			//parameters can be unnamed
+			if name != nil {
+				funcParam.Name = name.Name
+				funcParam.Pos = parsePosition(file, name.Pos())
+			}
+			funcParams = append(funcParams, funcParam)
+		}
 	}
 	return funcParams, nil
 }
 
-//ParseResults returns the results from the provided function
-func parseResults(astFunc *ast.FuncType) (results []*Result, err error) {
+//parseResults returns the results from the provided function, the results declared
+//together (a, b int) are split into separate results
+func parseResults(file parserGoFile, astFunc *ast.FuncType) (results []*Result, err error) {
 	funcResults := []*Result{}
 	if astFunc.Results == nil {
 		return funcResults, nil
 	}
-	astResults := astFunc.Results.List
-
-	for _, astResult := range astResults {
-		funcResult := &Result{}
-		//parse result names if there are any names assigned
-		if astResult.Names != nil {
-			funcResult.Name = astResult.Names[0].Name
-		}
 
-		funcResult.Type, err = convertFieldTypeToString(astResult)
+	for group, astResult := range astFunc.Results.List {
+		typeExpr, err := convertExpressionIntoTypeExpr(file, astResult.Type)
 		if err != nil {
 			return funcResults, err
 		}
-		funcResult.IsTypePointer = isPointer(astResult.Type)
-
-		funcResults = append(funcResults, funcResult)
+		resolvedType, underlyingType := resolveExpressionType(file, astResult.Type)
+
+		for _, name := range parseFieldNames(astResult) {
+			funcResult := &Result{
+				IsTypePointer:  isPointer(astResult.Type),
+				Type:           typeExpr.String(),
+				TypeExpr:       typeExpr,
+				ResolvedType:   resolvedType,
+				UnderlyingType: underlyingType,
+				Pos:            parsePosition(file, astResult.Pos()),
+				End:            parsePosition(file, astResult.End()),
+				group:          group + 1,
+			}
+			//results can be unnamed
+			if name != nil {
+				funcResult.Name = name.Name
+				funcResult.Pos = parsePosition(file, name.Pos())
+			}
+			funcResults = append(funcResults, funcResult)
+		}
 	}
 	return funcResults, nil
 }
 
+//parseFieldNames returns the names of the parameter or the field, a single nil name if it's unnamed
+func parseFieldNames(astField *ast.Field) []*ast.Ident {
+	if len(astField.Names) == 0 {
+		return []*ast.Ident{nil}
+	}
+	return astField.Names
+}
+
 //ParseComments returns the comments from the provided declaration
-func parseComments(astDecl ast.Decl) (*CommentGroup, error) {
+func parseComments(file parserGoFile, astDecl ast.Decl) (*CommentGroup, error) {
 	var astCommentGroup *ast.CommentGroup
 	commentGroup := &CommentGroup{}
 
@@ -265,15 +341,35 @@ func parseComments(astDecl ast.Decl) (*CommentGroup, error) {
 		return commentGroup, errors.New("declaration type not supported")
 	}
 
+	return convertCommentGroup(file, astCommentGroup), nil
+}
+
+//convertCommentGroup converts the ast comment group into this libraries representation of the comment group,
+//returns an empty comment group if there are no comments
+func convertCommentGroup(file parserGoFile, astCommentGroup *ast.CommentGroup) *CommentGroup {
+	commentGroup := &CommentGroup{}
+	commentGroup.PackageName = getPackageName(file)
 	if astCommentGroup == nil {
-		commentGroup = &CommentGroup{}
-		return commentGroup, nil
+		return commentGroup
 	}
+
 	for _, astComment := range astCommentGroup.List {
 		comment := Comment{}
 		comment.Text = astComment.Text
 		commentGroup.Comments = append(commentGroup.Comments, &comment)
 	}
+	commentGroup.file = &file
+	commentGroup.Pos = parsePosition(file, astCommentGroup.Pos())
+	commentGroup.End = parsePosition(file, astCommentGroup.End())
+	return commentGroup
+}
 
-	return commentGroup, nil
+//isBasicDataType checks if the name is one of the predeclared basic types
+func isBasicDataType(name string) bool {
+	for _, basicDataType := range golangBasicDataTypes {
+		if basicDataType == name {
+			return true
+		}
+	}
+	return false
 }
@@ -2,12 +2,8 @@ package parser
 
 import (
 	"go/ast"
-	"regexp"
 )
 
-var tagSplitRegex = regexp.MustCompile(":")
-var tagSyntaxRegex = regexp.MustCompile("[\"\x60]")
-
 func getStructs(file parserGoFile) (structs []*Struct, err error) {
 	genDecls := parseGenDeclarations(file)
 	structDecls := parseStructDecls(genDecls)
@@ -20,45 +16,28 @@ func getStructs(file parserGoFile) (structs []*Struct, err error) {
 	return structs, nil
 }
 
-//parseStructDeclsByName returns the first gen decl that contains the provided name
-func parseStructDeclsByName(name string, genDeclarations []*ast.GenDecl) *ast.GenDecl {
-	//loop over all general declarations in the file
-	for _, genDeclaration := range genDeclarations {
-		genDeclarationTypeSpec := genDeclaration.Specs[0]
-		switch typeSpec := genDeclarationTypeSpec.(type) {
-		case *ast.TypeSpec:
-			switch typeSpec.Type.(type) {
-			case *ast.StructType:
-				return genDeclaration
-			default:
-				continue
-			}
-		}
-	}
-
-	return nil
-}
-
-// takes in the file and returns only the general declarations with type struct
+// takes in the file and returns only the general declarations with at least one struct type spec,
+// a grouped type declaration can declare structs after the other types
 func parseStructDecls(genDeclarations []*ast.GenDecl) (structs []*ast.GenDecl) {
 	genDeclsWithStructType := []*ast.GenDecl{}
 	//loop over all general declarations in the file
-	for _, genDeclaration := range genDeclarations {
-		genDeclarationTypeSpec := genDeclaration.Specs[0]
-		switch typeSpec := genDeclarationTypeSpec.(type) {
-		case *ast.TypeSpec:
-			switch typeSpec.Type.(type) {
-			case *ast.StructType:
+	for _, genDeclaration := range parseTypeDecls(genDeclarations) {
+		for _, spec := range genDeclaration.Specs {
+			if isStructTypeSpec(spec.(*ast.TypeSpec)) {
 				genDeclsWithStructType = append(genDeclsWithStructType, genDeclaration)
-			default:
-				continue
+				break
 			}
 		}
-
 	}
 	return genDeclsWithStructType
 }
 
+//isStructTypeSpec checks if the type spec declares a struct type
+func isStructTypeSpec(typeSpec *ast.TypeSpec) bool {
+	_, ok := typeSpec.Type.(*ast.StructType)
+	return ok
+}
+
 func parseStructsByPackage(file parserGoFile) (structs []*Struct, err error) {
 	genDecls := parseGenDeclarations(file)
 	structDecls := parseStructDecls(genDecls)
@@ -74,51 +53,106 @@ func parseStructsByPackage(file parserGoFile) (structs []*Struct, err error) {
 func convertStructDeclsIntoStruct(file parserGoFile, genStructDecls []*ast.GenDecl) (structs []*Struct, err error) {
 
 	for _, genStructDecl := range genStructDecls {
-		theStruct := &Struct{}
-		genDeclSpec := genStructDecl.Specs[0].(*ast.TypeSpec)
-		theStruct.Name = genDeclSpec.Name.Name
-		for _, field := range genDeclSpec.Type.(*ast.StructType).Fields.List {
-			structField := &Field{}
-			if field.Names == nil {
-				structField.Name = ""
-			} else {
-				structField.Name = field.Names[0].Name
+		for _, spec := range genStructDecl.Specs {
+			genDeclSpec := spec.(*ast.TypeSpec)
+			if !isStructTypeSpec(genDeclSpec) {
+				continue
 			}
 
-			fieldTypeString, err := convertFieldTypeToString(field)
+			theStruct, err := convertStructSpecIntoStruct(file, genStructDecl, genDeclSpec)
 			if err != nil {
 				return nil, err
 			}
-			structField.Type = fieldTypeString
-
-			if field.Tag != nil {
-				tagValue := tagSplitRegex.Split(string(tagSyntaxRegex.ReplaceAll([]byte(field.Tag.Value), []byte(""))), -1)
-
-				structField.Tag = &Tag{
-					Type: field.Tag.Kind,
-					Value: TagValue{
-						Type:  tagValue[0],
-						Value: tagValue[1],
-					},
-				}
-			}
-
-			theStruct.Fields = append(theStruct.Fields, structField)
+			structs = append(structs, theStruct)
 		}
-		commentGroup, err := parseComments(genStructDecl)
+	}
+	return structs, nil
+}
+
+//convertStructSpecIntoStruct converts a single struct type spec of the general declaration
+func convertStructSpecIntoStruct(file parserGoFile, genStructDecl *ast.GenDecl, genDeclSpec *ast.TypeSpec) (
+	theStruct *Struct, err error) {
+
+	theStruct = &Struct{}
+	theStruct.PackageName = getPackageName(file)
+	theStruct.PackagePath = getPackagePath(file)
+	theStruct.Name = genDeclSpec.Name.Name
+	theStruct.Pos, theStruct.End = parseSpecRange(file, genStructDecl, genDeclSpec)
+	theStruct.TypeParams, err = parseTypeParams(file, genDeclSpec.TypeParams)
+	if err != nil {
+		return nil, err
+	}
+	theStruct.Fields, err = convertFieldListIntoFields(file, genDeclSpec.Type.(*ast.StructType).Fields)
+	if err != nil {
+		return nil, err
+	}
+	theStruct.Doc, theStruct.Directives = parseTypeSpecDoc(file, genStructDecl, genDeclSpec)
+	// get struct methods, they can be declared in any file of the package
+	methods, err := convertFunctionDeclsIntoMethod(file, packageMethodDecls(file, theStruct.Name))
+	if err != nil {
+		return nil, err
+	}
+	theStruct.Methods = methods
+	attachReceiverTypeParamConstraints(theStruct.Methods, theStruct.TypeParams)
+	theStruct.file = &file
+	return theStruct, nil
+}
+
+//convertFieldListIntoFields converts the struct fields into this libraries representation of fields,
+//fields declared together (A, B int) are split into separate fields
+func convertFieldListIntoFields(file parserGoFile, fieldList *ast.FieldList) (fields []*Field, err error) {
+	if fieldList == nil {
+		return fields, nil
+	}
+
+	for group, field := range fieldList.List {
+		typeExpr, err := convertExpressionIntoTypeExpr(file, field.Type)
 		if err != nil {
 			return nil, err
 		}
-		theStruct.Doc = commentGroup
-		// get struct methods
-		funcDecls := parseFuncDeclarations(file)
-		astMethods := parseMethodDecls(funcDecls)
-		methods, err := convertFunctionDeclsIntoMethod(astMethods)
-		if err != nil {
-			return nil, err
+
+		var tag *Tag
+		if field.Tag != nil {
+			tag = parseTag(field.Tag.Value)
+		}
+
+		// embedded fields don't have names
+		for _, name := range parseFieldNames(field) {
+			structField := &Field{
+				Embedded:      name == nil,
+				IsTypePointer: isPointer(field.Type),
+				Type:          typeExpr.String(),
+				TypeExpr:      typeExpr,
+				Tag:           tag,
+				Pos:           parsePosition(file, field.Pos()),
+				End:           parsePosition(file, field.End()),
+				group:         group + 1,
+			}
+			structField.ResolvedType, structField.UnderlyingType = resolveExpressionType(file, field.Type)
+			structField.Directives = parseDirectives(file, field.Doc, field.Comment)
+			if field.Doc != nil {
+				structField.Doc = convertCommentGroup(file, field.Doc)
+			}
+			if field.Comment != nil {
+				structField.Comment = convertCommentGroup(file, field.Comment)
+			}
+			if name != nil {
+				structField.Name = name.Name
+				structField.Pos = parsePosition(file, name.Pos())
+			} else {
+				structField.Name = embeddedFieldName(typeExpr)
+			}
+			fields = append(fields, structField)
 		}
-		theStruct.Methods = methods
-		structs = append(structs, theStruct)
 	}
-	return structs, nil
+	return fields, nil
+}
+
+//embeddedFieldName returns the name of the embedded field, the name of the type without the package,
+//the pointer and the type arguments, Mutex for *sync.Mutex
+func embeddedFieldName(typeExpr *TypeExpr) string {
+	if typeExpr.Kind == PointerTypeExpr {
+		typeExpr = typeExpr.Elem
+	}
+	return typeExpr.Name
 }
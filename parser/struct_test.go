@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestGroupedTypeDeclarations(t *testing.T) {
+	par := mustParseSource(t, `package p
+
+type (
+	// Named is not a struct
+	Named int
+
+	// A is the first struct
+	A struct{}
+
+	// B is declared after the first spec
+	B struct {
+		Name string
+	}
+
+	I interface{ M() }
+)
+`)
+
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{name: "A", doc: "A is the first struct"},
+		{name: "B", doc: "B is declared after the first spec"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			theStruct, err := par.GetStruct(test.name)
+			if err != nil {
+				t.Fatalf("GetStruct(%q) error = %v", test.name, err)
+			}
+			if doc := theStruct.Doc.Text(); doc != test.doc+"\n" {
+				t.Errorf("GetStruct(%q).Doc = %q, want %q", test.name, doc, test.doc+"\n")
+			}
+		})
+	}
+
+	structs, err := par.GetStructs()
+	if err != nil {
+		t.Fatalf("GetStructs() error = %v", err)
+	}
+	if len(structs) != 2 {
+		t.Errorf("GetStructs() returned %d structs, want 2", len(structs))
+	}
+	if _, err := par.GetInterface("I"); err != nil {
+		t.Errorf("GetInterface(%q) error = %v", "I", err)
+	}
+}
+
+func TestEmptyGroupedDeclarations(t *testing.T) {
+	par := mustParseSource(t, `package p
+
+type ()
+
+type A struct{}
+`)
+
+	structs, err := par.GetStructs()
+	if err != nil {
+		t.Fatalf("GetStructs() error = %v", err)
+	}
+	if len(structs) != 1 {
+		t.Errorf("GetStructs() returned %d structs, want 1", len(structs))
+	}
+	if _, err := par.GetInterfaces(); err != nil {
+		t.Errorf("GetInterfaces() error = %v", err)
+	}
+}
+
+func TestEmptyDeclarationGroups(t *testing.T) {
+	par := mustParseSource(t, `package p
+
+import ()
+
+const ()
+
+var ()
+
+type A struct{}
+`)
+
+	if _, err := par.GetStructs(); err != nil {
+		t.Errorf("GetStructs() error = %v", err)
+	}
+	if _, err := par.GetVariables(); err != nil {
+		t.Errorf("GetVariables() error = %v", err)
+	}
+	if _, err := par.GetImports(); err != nil {
+		t.Errorf("GetImports() error = %v", err)
+	}
+	if _, err := par.GetPackages(); err != nil {
+		t.Errorf("GetPackages() error = %v", err)
+	}
+}
+
+func TestGenericDeclarationStrings(t *testing.T) {
+	const source = "package p\n\n" +
+		"type Pair[K comparable, V any] struct {\n" +
+		"\tKey   K `json:\"key\"`\n" +
+		"\tValue V \"raw:\\\"`\\\"\"\n" +
+		"}\n\n" +
+		"type Getter[T any] interface {\n" +
+		"\tGet() T\n" +
+		"}\n"
+	par := mustParseSource(t, source)
+
+	theStruct, err := par.GetStruct("Pair")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "Pair", err)
+	}
+	gotStruct, err := theStruct.String()
+	if err != nil {
+		t.Fatalf("Struct.String() error = %v", err)
+	}
+	wantStruct := "type Pair[K comparable, V any] struct { \n" +
+		"  Key K   `json:\"key\"`\n" +
+		"  Value V   \"raw:\\\"`\\\"\"\n" +
+		"\n}\n"
+	if gotStruct != wantStruct {
+		t.Errorf("Struct.String() = %q, want %q", gotStruct, wantStruct)
+	}
+
+	theInterface, err := par.GetInterface("Getter")
+	if err != nil {
+		t.Fatalf("GetInterface(%q) error = %v", "Getter", err)
+	}
+	gotInterface, err := theInterface.String()
+	if err != nil {
+		t.Fatalf("Interface.String() error = %v", err)
+	}
+	wantInterface := "type Getter[T any] interface { \n" +
+		"  Get() T\n" +
+		"}\n"
+	if gotInterface != wantInterface {
+		t.Errorf("Interface.String() = %q, want %q", gotInterface, wantInterface)
+	}
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+//parseTag parses the struct tag literal, the keys are parsed the same way as reflect.StructTag does it,
+//parsing stops at the first key that is not in the conventional format
+func parseTag(tagLiteral string) *Tag {
+	raw, err := strconv.Unquote(tagLiteral)
+	if err != nil {
+		raw = strings.Trim(tagLiteral, "`\"")
+	}
+
+	tag := &Tag{Raw: raw}
+	rest := raw
+	for rest != "" {
+		// skip the leading space
+		i := 0
+		for i < len(rest) && rest[i] == ' ' {
+			i++
+		}
+		rest = rest[i:]
+		if rest == "" {
+			break
+		}
+
+		// the key is a non empty string of non control characters that are not space, quote or colon
+		i = 0
+		for i < len(rest) && rest[i] > ' ' && rest[i] != ':' && rest[i] != '"' && rest[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(rest) || rest[i] != ':' || rest[i+1] != '"' {
+			break
+		}
+		key := rest[:i]
+		rest = rest[i+1:]
+
+		// the value is a quoted string
+		i = 1
+		for i < len(rest) && rest[i] != '"' {
+			if rest[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(rest) {
+			break
+		}
+		quotedValue := rest[:i+1]
+		rest = rest[i+1:]
+
+		value, err := strconv.Unquote(quotedValue)
+		if err != nil {
+			break
+		}
+
+		valueParts := strings.Split(value, ",")
+		tag.Keys = append(tag.Keys, &TagKey{
+			Key:     key,
+			Value:   value,
+			Name:    valueParts[0],
+			Options: valueParts[1:],
+		})
+	}
+	return tag
+}
+
+//Lookup returns the key of the tag with the provided name
+func (t Tag) Lookup(key string) (tagKey *TagKey, ok bool) {
+	for _, tagKey := range t.Keys {
+		if tagKey.Key == key {
+			return tagKey, true
+		}
+	}
+	return nil, false
+}
+
+//Get returns the value of the key with the provided name, returns empty string if the key does not exist
+func (t Tag) Get(key string) string {
+	tagKey, ok := t.Lookup(key)
+	if !ok {
+		return ""
+	}
+	return tagKey.Value
+}
+
+//Literal renders the tag back into a go literal, using backticks when possible
+func (t Tag) Literal() string {
+	if strings.Contains(t.Raw, "`") {
+		return strconv.Quote(t.Raw)
+	}
+	return "`" + t.Raw + "`"
+}
+
+//HasOption checks if the tag key has the provided option, omitempty in json:"name,omitempty"
+func (tk TagKey) HasOption(option string) bool {
+	for _, tagOption := range tk.Options {
+		if tagOption == option {
+			return true
+		}
+	}
+	return false
+}
+
+//TagLookup returns the key of the field tag with the provided name, json for json:"name,omitempty"
+func (f Field) TagLookup(key string) (tagKey *TagKey, ok bool) {
+	if f.Tag == nil {
+		return nil, false
+	}
+	return f.Tag.Lookup(key)
+}
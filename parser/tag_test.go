@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		literal     string
+		wantRaw     string
+		wantKeys    []string
+		wantLiteral string
+	}{
+		{
+			name:        "keys with options",
+			literal:     "`json:\"name,omitempty\" db:\"user_name\"`",
+			wantRaw:     `json:"name,omitempty" db:"user_name"`,
+			wantKeys:    []string{"json", "db"},
+			wantLiteral: "`json:\"name,omitempty\" db:\"user_name\"`",
+		},
+		{
+			name:        "escaped quotes",
+			literal:     "`validate:\"oneof=\\\"a b\\\"\"`",
+			wantRaw:     `validate:"oneof=\"a b\""`,
+			wantKeys:    []string{"validate"},
+			wantLiteral: "`validate:\"oneof=\\\"a b\\\"\"`",
+		},
+		{
+			name:        "interpreted literal with a backtick",
+			literal:     `"sql:\"` + "`id`" + `\""`,
+			wantRaw:     "sql:\"`id`\"",
+			wantKeys:    []string{"sql"},
+			wantLiteral: `"sql:\"` + "`id`" + `\""`,
+		},
+		{
+			name:        "extra spaces",
+			literal:     "`  json:\"a\"   xml:\"b\" `",
+			wantRaw:     `  json:"a"   xml:"b" `,
+			wantKeys:    []string{"json", "xml"},
+			wantLiteral: "`  json:\"a\"   xml:\"b\" `",
+		},
+		{
+			name:        "parsing stops at an unconventional key",
+			literal:     "`json:\"a\" broken xml:\"b\"`",
+			wantRaw:     `json:"a" broken xml:"b"`,
+			wantKeys:    []string{"json"},
+			wantLiteral: "`json:\"a\" broken xml:\"b\"`",
+		},
+		{
+			name:        "unquoted value",
+			literal:     "`json:a`",
+			wantRaw:     `json:a`,
+			wantLiteral: "`json:a`",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tag := parseTag(test.literal)
+			if tag.Raw != test.wantRaw {
+				t.Errorf("parseTag(%s).Raw = %q, want %q", test.literal, tag.Raw, test.wantRaw)
+			}
+			keys := []string{}
+			for _, tagKey := range tag.Keys {
+				keys = append(keys, tagKey.Key)
+			}
+			if len(test.wantKeys) == 0 {
+				test.wantKeys = []string{}
+			}
+			if !reflect.DeepEqual(keys, test.wantKeys) {
+				t.Errorf("parseTag(%s) keys = %v, want %v", test.literal, keys, test.wantKeys)
+			}
+			if literal := tag.Literal(); literal != test.wantLiteral {
+				t.Errorf("parseTag(%s).Literal() = %s, want %s", test.literal, literal, test.wantLiteral)
+			}
+
+			// the values are the same as the ones reflect reads
+			structTag := reflect.StructTag(tag.Raw)
+			for _, tagKey := range tag.Keys {
+				value, ok := structTag.Lookup(tagKey.Key)
+				if !ok || value != tagKey.Value {
+					t.Errorf("parseTag(%s) %s = %q, reflect = %q, %t", test.literal, tagKey.Key, tagKey.Value, value, ok)
+				}
+			}
+		})
+	}
+}
+
+func TestTagLookup(t *testing.T) {
+	tag := parseTag("`json:\"name,omitempty,string\" db:\"-\"`")
+
+	jsonKey, ok := tag.Lookup("json")
+	if !ok {
+		t.Fatalf("Lookup(%q) ok = false, want true", "json")
+	}
+	if jsonKey.Name != "name" || !reflect.DeepEqual(jsonKey.Options, []string{"omitempty", "string"}) {
+		t.Errorf("Lookup(%q) = %s %v, want name [omitempty string]", "json", jsonKey.Name, jsonKey.Options)
+	}
+	if !jsonKey.HasOption("omitempty") || jsonKey.HasOption("inline") {
+		t.Errorf("HasOption() = %t %t, want true false", jsonKey.HasOption("omitempty"), jsonKey.HasOption("inline"))
+	}
+	if _, ok := tag.Lookup("xml"); ok {
+		t.Errorf("Lookup(%q) ok = true, want false", "xml")
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "json", want: "name,omitempty,string"},
+		{key: "db", want: "-"},
+		{key: "xml", want: ""},
+	}
+	for _, test := range tests {
+		if got := tag.Get(test.key); got != test.want {
+			t.Errorf("Get(%q) = %q, want %q", test.key, got, test.want)
+		}
+	}
+}
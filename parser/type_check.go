@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+)
+
+//packagesImporter imports the parsed packages by type checking them, the other packages are imported from their source
+type packagesImporter struct {
+	fset     *token.FileSet
+	packages []*parserPackage
+	fallback types.Importer
+	checked  map[*parserPackage]*types.Package
+}
+
+//typeCheckPackages type checks all the parsed packages with go/types, the type errors are ignored
+//so the packages with missing or broken dependencies still get the types that can be resolved
+func typeCheckPackages(fset *token.FileSet, packages []*parserPackage) {
+	packagesImporter := &packagesImporter{
+		fset:     fset,
+		packages: packages,
+		fallback: importer.ForCompiler(fset, "source", nil),
+		checked:  map[*parserPackage]*types.Package{},
+	}
+	for _, pkg := range packages {
+		packagesImporter.check(pkg)
+	}
+}
+
+//Import implements types.Importer
+func (pi *packagesImporter) Import(importPath string) (*types.Package, error) {
+	for _, pkg := range pi.packages {
+		if pkg.ImportPath == "" || pkg.ImportPath != importPath {
+			continue
+		}
+		typesPackage := pi.check(pkg)
+		if typesPackage == nil {
+			return nil, fmt.Errorf("import cycle through %s", importPath)
+		}
+		return typesPackage, nil
+	}
+	return pi.fallback.Import(importPath)
+}
+
+//check type checks the package once, returns nil while the package is being checked
+func (pi *packagesImporter) check(pkg *parserPackage) *types.Package {
+	if typesPackage, ok := pi.checked[pkg]; ok {
+		return typesPackage
+	}
+	pi.checked[pkg] = nil
+
+	astFiles := []*ast.File{}
+	for _, file := range pkg.GoFiles {
+		astFiles = append(astFiles, file.AstFile)
+	}
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+	}
+	config := types.Config{
+		Importer: pi,
+		Error:    func(err error) {},
+	}
+
+	typesPackage, _ := config.Check(pkg.ImportPath, pi.fset, astFiles, info)
+	pkg.TypesInfo = info
+	pi.checked[pkg] = typesPackage
+	return typesPackage
+}
+
+//resolveExpressionType returns the resolved and the underlying type of the type expression,
+//empty if the packages are not type checked or the type can't be resolved
+func resolveExpressionType(file parserGoFile, expression ast.Expr) (resolvedType string, underlyingType string) {
+	if file.Package == nil || file.Package.TypesInfo == nil {
+		return "", ""
+	}
+	return typeStrings(file.Package.TypesInfo.TypeOf(expression))
+}
+
+//resolveIdentType returns the resolved and the underlying type of the declared name
+func resolveIdentType(file parserGoFile, ident *ast.Ident) (resolvedType string, underlyingType string) {
+	if file.Package == nil || file.Package.TypesInfo == nil {
+		return "", ""
+	}
+	object := file.Package.TypesInfo.Defs[ident]
+	if object == nil {
+		return "", ""
+	}
+	return typeStrings(object.Type())
+}
+
+//typeStrings returns the type and its underlying type with the packages qualified by their import path
+func typeStrings(theType types.Type) (resolvedType string, underlyingType string) {
+	if theType == nil || theType == types.Typ[types.Invalid] {
+		return "", ""
+	}
+	return types.TypeString(theType, nil), types.TypeString(theType.Underlying(), nil)
+}
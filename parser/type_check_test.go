@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestTypeCheck(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/app\n")},
+		"db/db.go": {Data: []byte(`package db
+
+type Conn struct{}
+
+type Status string
+
+type Statuses []Status
+`)},
+		"app/app.go": {Data: []byte(`package app
+
+import (
+	"errors"
+
+	"example.com/app/db"
+)
+
+type Service struct {
+	Conn     *db.Conn
+	Status   db.Status
+	Statuses db.Statuses
+	Err      error
+}
+
+func (s *Service) Open(conn db.Conn) (db.Status, error) { return "", nil }
+
+var Default = db.Status("ok")
+
+var ErrClosed = errors.New("closed")
+
+var count = 1 << 3
+`)},
+	}
+	_, iparser, err := NewParserFS(fsys, ".", ParserOptions{Recursive: true, TypeCheck: true})
+	if err != nil {
+		t.Fatalf("NewParserFS() error = %v", err)
+	}
+	par := iparser.(*Parser)
+
+	service, err := par.GetStruct("Service")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "Service", err)
+	}
+	fieldTests := []struct {
+		name           string
+		wantResolved   string
+		wantUnderlying string
+	}{
+		{name: "Conn", wantResolved: "*example.com/app/db.Conn", wantUnderlying: "*example.com/app/db.Conn"},
+		{name: "Status", wantResolved: "example.com/app/db.Status", wantUnderlying: "string"},
+		{name: "Statuses", wantResolved: "example.com/app/db.Statuses", wantUnderlying: "[]example.com/app/db.Status"},
+		{name: "Err", wantResolved: "error", wantUnderlying: "interface{Error() string}"},
+	}
+	for _, test := range fieldTests {
+		var field *Field
+		for _, serviceField := range service.Fields {
+			if serviceField.Name == test.name {
+				field = serviceField
+			}
+		}
+		if field == nil {
+			t.Errorf("field %s not found", test.name)
+			continue
+		}
+		if field.ResolvedType != test.wantResolved || field.UnderlyingType != test.wantUnderlying {
+			t.Errorf("field %s types = %q %q, want %q %q",
+				test.name, field.ResolvedType, field.UnderlyingType, test.wantResolved, test.wantUnderlying)
+		}
+	}
+
+	method := service.Methods[0]
+	if got, want := method.Params[0].ResolvedType, "example.com/app/db.Conn"; got != want {
+		t.Errorf("Open param ResolvedType = %q, want %q", got, want)
+	}
+	if got, want := method.Results[0].UnderlyingType, "string"; got != want {
+		t.Errorf("Open result UnderlyingType = %q, want %q", got, want)
+	}
+
+	variableTests := []struct {
+		name           string
+		wantResolved   string
+		wantUnderlying string
+	}{
+		{name: "Default", wantResolved: "example.com/app/db.Status", wantUnderlying: "string"},
+		{name: "ErrClosed", wantResolved: "error", wantUnderlying: "interface{Error() string}"},
+		{name: "count", wantResolved: "int", wantUnderlying: "int"},
+	}
+	for _, test := range variableTests {
+		variable, err := par.GetVariable(test.name)
+		if err != nil {
+			t.Errorf("GetVariable(%q) error = %v", test.name, err)
+			continue
+		}
+		if variable.ResolvedType != test.wantResolved || variable.UnderlyingType != test.wantUnderlying {
+			t.Errorf("GetVariable(%q) types = %q %q, want %q %q",
+				test.name, variable.ResolvedType, variable.UnderlyingType, test.wantResolved, test.wantUnderlying)
+		}
+	}
+}
+
+func TestTypeCheckDisabled(t *testing.T) {
+	par := mustParseSource(t, "package p\n\ntype T struct{ N int }\n\nvar V = 1\n")
+
+	theStruct, err := par.GetStruct("T")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "T", err)
+	}
+	if field := theStruct.Fields[0]; field.ResolvedType != "" || field.UnderlyingType != "" {
+		t.Errorf("field N types = %q %q, want empty without type checking", field.ResolvedType, field.UnderlyingType)
+	}
+	variable, err := par.GetVariable("V")
+	if err != nil {
+		t.Fatalf("GetVariable(%q) error = %v", "V", err)
+	}
+	if variable.ResolvedType != "" {
+		t.Errorf("GetVariable(%q).ResolvedType = %q, want empty without type checking", "V", variable.ResolvedType)
+	}
+}
@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func getTypes(file parserGoFile) (types []*TypeDecl, err error) {
+	genDecls := parseGenDeclarations(file)
+	typeDecls := parseTypeDecls(genDecls)
+	fileTypes, err := convertTypeDeclsIntoTypeDecl(file, typeDecls)
+	if err != nil {
+		return nil, err
+	}
+	types = append(types, fileTypes...)
+
+	return types, nil
+}
+
+//parseTypeDecls returns only the type declarations from the provided declarations
+func parseTypeDecls(genDecls []*ast.GenDecl) (typeDecls []*ast.GenDecl) {
+	for _, genDecl := range genDecls {
+		switch genDecl.Tok {
+		case token.TYPE:
+			typeDecls = append(typeDecls, genDecl)
+		}
+	}
+	return typeDecls
+}
+
+//isNamedTypeSpec checks if the type spec declares a named type that is not a struct or an interface,
+//type aliases are always named types
+func isNamedTypeSpec(typeSpec *ast.TypeSpec) bool {
+	if typeSpec.Assign.IsValid() {
+		return true
+	}
+
+	switch typeSpec.Type.(type) {
+	case *ast.StructType, *ast.InterfaceType:
+		return false
+	default:
+		return true
+	}
+}
+
+// takes in ast type declarations and returns this libraries representation of named types
+func convertTypeDeclsIntoTypeDecl(file parserGoFile, genTypeDecls []*ast.GenDecl) (types []*TypeDecl, err error) {
+	for _, genTypeDecl := range genTypeDecls {
+		for _, spec := range genTypeDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if !isNamedTypeSpec(typeSpec) {
+				continue
+			}
+
+			theType, err := convertTypeSpecIntoTypeDecl(file, genTypeDecl, typeSpec)
+			if err != nil {
+				return nil, err
+			}
+			types = append(types, theType)
+		}
+	}
+	return types, nil
+}
+
+//convertTypeSpecIntoTypeDecl converts a single named type spec of the general declaration
+func convertTypeSpecIntoTypeDecl(file parserGoFile, genTypeDecl *ast.GenDecl, typeSpec *ast.TypeSpec) (
+	theType *TypeDecl, err error) {
+
+	theType = &TypeDecl{}
+	theType.PackageName = getPackageName(file)
+	theType.PackagePath = getPackagePath(file)
+	theType.Name = typeSpec.Name.Name
+	theType.Pos, theType.End = parseSpecRange(file, genTypeDecl, typeSpec)
+	theType.IsAlias = typeSpec.Assign.IsValid()
+	theType.TypeParams, err = parseTypeParams(file, typeSpec.TypeParams)
+	if err != nil {
+		return nil, err
+	}
+	theType.TypeExpr, err = convertExpressionIntoTypeExpr(file, typeSpec.Type)
+	if err != nil {
+		return nil, err
+	}
+	theType.Type = theType.TypeExpr.String()
+
+	theType.Doc, theType.Directives = parseTypeSpecDoc(file, genTypeDecl, typeSpec)
+
+	// aliases share the methods of the type they alias
+	if !theType.IsAlias {
+		theType.Methods, err = convertFunctionDeclsIntoMethod(file, packageMethodDecls(file, theType.Name))
+		if err != nil {
+			return nil, err
+		}
+		attachReceiverTypeParamConstraints(theType.Methods, theType.TypeParams)
+	}
+	return theType, nil
+}
+
+//findTypeSpecDecl returns the general declaration of the file the type spec is declared in
+func findTypeSpecDecl(file parserGoFile, typeSpec *ast.TypeSpec) *ast.GenDecl {
+	for _, genDecl := range parseTypeDecls(parseGenDeclarations(file)) {
+		for _, spec := range genDecl.Specs {
+			if spec == ast.Spec(typeSpec) {
+				return genDecl
+			}
+		}
+	}
+	return nil
+}
+
+//parseTypeSpecDoc returns the doc and the directives of the type spec,
+//a single type declaration without parentheses has the doc on the general declaration
+func parseTypeSpecDoc(file parserGoFile, genDecl *ast.GenDecl, typeSpec *ast.TypeSpec) (
+	doc *CommentGroup, directives []Directive) {
+
+	astDoc := typeSpec.Doc
+	if astDoc == nil && !genDecl.Lparen.IsValid() {
+		astDoc = genDecl.Doc
+	}
+	return convertCommentGroup(file, astDoc), parseDirectives(file, astDoc, typeSpec.Comment)
+}
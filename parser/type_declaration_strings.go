@@ -1,16 +1,17 @@
 package parser
 
-const StructTemplateString = "type {{ .Name }} struct { \n" +
-	"{{ range .Fields }}  {{ if .Name }}{{ .Name }} {{ .Type }} {{ else }}{{ .Type }}{{ end }}  " +
-	"{{ if .Tag }}`{{ .Tag.Value.Type }}:\"{{ .Tag.Value.Value }}\"`{{ end }}\n" +
+const StructTemplateString = "type {{ .Name }}{{ typeParams .TypeParams }} struct { \n" +
+	"{{ range .Fields }}  {{ if .Embedded }}{{ .Type }}{{ else }}{{ .Name }} {{ .Type }} {{ end }}  " +
+	"{{ if .Tag }}{{ .Tag.Literal }}{{ end }}\n" +
 	"{{ end }}\n" +
 	"}\n"
 
 const FunctionTemplateString = "r"
 
-const InterfaceTemplateString = "type {{ .Name }} interface { \n" +
-	"{{ range .Methods }}  {{ .Name }}({{ range .Params }}{{ .Name }} {{ .Type }}{{ end }})" +
-	"{{ range .Results }}{{ .Name }} {{ .Type }} {{ end }}\n{{ end }}" +
+const InterfaceTemplateString = "type {{ .Name }}{{ typeParams .TypeParams }} interface { \n" +
+	"{{ range .Embeds }}  {{ .String }}\n{{ end }}" +
+	"{{ range .TypeSet }}  {{ .String }}\n{{ end }}" +
+	"{{ range .Methods }}  {{ .Name }}{{ signature .Params .Results }}\n{{ end }}" +
 	"}\n"
 
-const ImportTemplateString = "{{ .Name }} {{ .Path }}"
+const ImportTemplateString = "{{ if .Name }}{{ .Name }} {{ end }}{{ printf \"%q\" .Path }}"
@@ -0,0 +1,321 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+//convertExpressionIntoTypeExpr takes in an ast type expression and returns this libraries representation of the type
+func convertExpressionIntoTypeExpr(file parserGoFile, expression ast.Expr) (typeExpr *TypeExpr, err error) {
+	switch expression := expression.(type) {
+	case *ast.Ident:
+		typeExpr = &TypeExpr{Kind: NamedTypeExpr, Name: expression.Name}
+		if !isDeclaredInPackage(file, expression.Name) {
+			typeExpr.ImportPath, typeExpr.AmbiguousImportPath = resolveDotImportPath(file, expression)
+		}
+		return typeExpr, nil
+	case *ast.SelectorExpr:
+		packageIdent, ok := expression.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("type expression not supported: %s", types.ExprString(expression))
+		}
+		return &TypeExpr{
+			Kind:       NamedTypeExpr,
+			Package:    packageIdent.Name,
+			ImportPath: resolveImportPath(file, packageIdent.Name),
+			Name:       expression.Sel.Name,
+		}, nil
+	case *ast.ParenExpr:
+		return convertExpressionIntoTypeExpr(file, expression.X)
+	case *ast.StarExpr:
+		return convertElemExpressionIntoTypeExpr(file, PointerTypeExpr, expression.X)
+	case *ast.Ellipsis:
+		return convertElemExpressionIntoTypeExpr(file, EllipsisTypeExpr, expression.Elt)
+	case *ast.ArrayType:
+		if expression.Len == nil {
+			return convertElemExpressionIntoTypeExpr(file, SliceTypeExpr, expression.Elt)
+		}
+		typeExpr, err = convertElemExpressionIntoTypeExpr(file, ArrayTypeExpr, expression.Elt)
+		if err != nil {
+			return nil, err
+		}
+		typeExpr.Len = types.ExprString(expression.Len)
+		return typeExpr, nil
+	case *ast.MapType:
+		typeExpr, err = convertElemExpressionIntoTypeExpr(file, MapTypeExpr, expression.Value)
+		if err != nil {
+			return nil, err
+		}
+		typeExpr.Key, err = convertExpressionIntoTypeExpr(file, expression.Key)
+		if err != nil {
+			return nil, err
+		}
+		return typeExpr, nil
+	case *ast.ChanType:
+		typeExpr, err = convertElemExpressionIntoTypeExpr(file, ChanTypeExpr, expression.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch expression.Dir {
+		case ast.SEND:
+			typeExpr.ChanDir = SendChan
+		case ast.RECV:
+			typeExpr.ChanDir = RecvChan
+		default:
+			typeExpr.ChanDir = BothChan
+		}
+		return typeExpr, nil
+	case *ast.FuncType:
+		typeExpr = &TypeExpr{Kind: FuncTypeExpr, Func: &FuncSignature{}}
+		typeExpr.Func.Params, err = parseParameters(file, expression)
+		if err != nil {
+			return nil, err
+		}
+		typeExpr.Func.Results, err = parseResults(file, expression)
+		if err != nil {
+			return nil, err
+		}
+		return typeExpr, nil
+	case *ast.StructType:
+		typeExpr = &TypeExpr{Kind: StructTypeExpr}
+		typeExpr.Fields, err = convertFieldListIntoFields(file, expression.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return typeExpr, nil
+	case *ast.InterfaceType:
+		typeExpr = &TypeExpr{Kind: InterfaceTypeExpr}
+		typeExpr.Methods, typeExpr.Embeds, err = convertInterfaceMethodList(file, expression.Methods)
+		if err != nil {
+			return nil, err
+		}
+		return typeExpr, nil
+	case *ast.IndexExpr:
+		return convertInstantiationIntoTypeExpr(file, expression.X, expression.Index)
+	case *ast.IndexListExpr:
+		return convertInstantiationIntoTypeExpr(file, expression.X, expression.Indices...)
+	case *ast.UnaryExpr:
+		if expression.Op != token.TILDE {
+			return nil, fmt.Errorf("type expression not supported: %s", types.ExprString(expression))
+		}
+		typeExpr, err = convertExpressionIntoTypeExpr(file, expression.X)
+		if err != nil {
+			return nil, err
+		}
+		typeExpr.Tilde = true
+		return typeExpr, nil
+	case *ast.BinaryExpr:
+		if expression.Op != token.OR {
+			return nil, fmt.Errorf("type expression not supported: %s", types.ExprString(expression))
+		}
+		return convertUnionIntoTypeExpr(file, expression)
+	default:
+		return nil, fmt.Errorf("type expression not supported: %s", types.ExprString(expression))
+	}
+}
+
+//convertElemExpressionIntoTypeExpr creates the type of the provided kind with the element type
+func convertElemExpressionIntoTypeExpr(file parserGoFile, kind TypeExprKind, elemExpression ast.Expr) (typeExpr *TypeExpr, err error) {
+	elem, err := convertExpressionIntoTypeExpr(file, elemExpression)
+	if err != nil {
+		return nil, err
+	}
+	return &TypeExpr{Kind: kind, Elem: elem}, nil
+}
+
+//convertInstantiationIntoTypeExpr converts an instantiated generic type, like List[int]
+func convertInstantiationIntoTypeExpr(file parserGoFile, genericExpression ast.Expr, typeArgExpressions ...ast.Expr) (typeExpr *TypeExpr, err error) {
+	typeExpr, err = convertExpressionIntoTypeExpr(file, genericExpression)
+	if err != nil {
+		return nil, err
+	}
+	for _, typeArgExpression := range typeArgExpressions {
+		typeArg, err := convertExpressionIntoTypeExpr(file, typeArgExpression)
+		if err != nil {
+			return nil, err
+		}
+		typeExpr.TypeArgs = append(typeExpr.TypeArgs, typeArg)
+	}
+	return typeExpr, nil
+}
+
+//convertUnionIntoTypeExpr converts a type set union, like ~int | ~string, the terms are flattened
+func convertUnionIntoTypeExpr(file parserGoFile, expression *ast.BinaryExpr) (typeExpr *TypeExpr, err error) {
+	typeExpr = &TypeExpr{Kind: UnionTypeExpr}
+	for _, termExpression := range []ast.Expr{expression.X, expression.Y} {
+		term, err := convertExpressionIntoTypeExpr(file, termExpression)
+		if err != nil {
+			return nil, err
+		}
+		if term.Kind == UnionTypeExpr && !term.Tilde {
+			typeExpr.Terms = append(typeExpr.Terms, term.Terms...)
+			continue
+		}
+		typeExpr.Terms = append(typeExpr.Terms, term)
+	}
+	return typeExpr, nil
+}
+
+//convertTypeExpressionToString converts the provided type expression into a string representation
+func convertTypeExpressionToString(file parserGoFile, expression ast.Expr) (string, error) {
+	typeExpr, err := convertExpressionIntoTypeExpr(file, expression)
+	if err != nil {
+		return "", err
+	}
+	return typeExpr.String(), nil
+}
+
+//String renders the type back into go source
+func (t TypeExpr) String() string {
+	typeString := ""
+	switch t.Kind {
+	case NamedTypeExpr:
+		typeString = t.Name
+		if t.Package != "" {
+			typeString = fmt.Sprintf("%s.%s", t.Package, t.Name)
+		}
+		if len(t.TypeArgs) != 0 {
+			typeArgs := []string{}
+			for _, typeArg := range t.TypeArgs {
+				typeArgs = append(typeArgs, typeArg.String())
+			}
+			typeString = fmt.Sprintf("%s[%s]", typeString, strings.Join(typeArgs, ", "))
+		}
+	case PointerTypeExpr:
+		typeString = fmt.Sprintf("*%s", t.Elem)
+	case SliceTypeExpr:
+		typeString = fmt.Sprintf("[]%s", t.Elem)
+	case ArrayTypeExpr:
+		typeString = fmt.Sprintf("[%s]%s", t.Len, t.Elem)
+	case MapTypeExpr:
+		typeString = fmt.Sprintf("map[%s]%s", t.Key, t.Elem)
+	case ChanTypeExpr:
+		switch t.ChanDir {
+		case SendChan:
+			typeString = fmt.Sprintf("chan<- %s", t.Elem)
+		case RecvChan:
+			typeString = fmt.Sprintf("<-chan %s", t.Elem)
+		default:
+			// chan (<-chan T) needs the parentheses, otherwise it's parsed as chan<- (chan T)
+			if t.Elem.Kind == ChanTypeExpr && t.Elem.ChanDir == RecvChan {
+				typeString = fmt.Sprintf("chan (%s)", t.Elem)
+			} else {
+				typeString = fmt.Sprintf("chan %s", t.Elem)
+			}
+		}
+	case FuncTypeExpr:
+		typeString = fmt.Sprintf("func%s", signatureString(t.Func.Params, t.Func.Results))
+	case StructTypeExpr:
+		fields := []namedTypeString{}
+		for _, field := range t.Fields {
+			if field.Embedded {
+				fields = append(fields, namedTypeString{typeString: fieldString(field)})
+				continue
+			}
+			fieldType := field.Type
+			if field.Tag != nil {
+				fieldType = fmt.Sprintf("%s %s", fieldType, field.Tag.Literal())
+			}
+			fields = append(fields, namedTypeString{name: field.Name, typeString: fieldType, group: field.group})
+		}
+		typeString = fmt.Sprintf("struct{%s}", joinNamedTypes(fields, "; "))
+	case InterfaceTypeExpr:
+		elements := []string{}
+		for _, embed := range t.Embeds {
+			elements = append(elements, embed.String())
+		}
+		for _, method := range t.Methods {
+			elements = append(elements, method.Name+signatureString(method.Params, method.Results))
+		}
+		typeString = fmt.Sprintf("interface{%s}", strings.Join(elements, "; "))
+	case EllipsisTypeExpr:
+		typeString = fmt.Sprintf("...%s", t.Elem)
+	case UnionTypeExpr:
+		terms := []string{}
+		for _, term := range t.Terms {
+			terms = append(terms, term.String())
+		}
+		typeString = strings.Join(terms, " | ")
+	}
+
+	if t.Tilde {
+		return "~" + typeString
+	}
+	return typeString
+}
+
+//signatureString renders the parameters and results of a function, like (a int) (string, error)
+func signatureString(params []*Parameter, results []*Result) string {
+	paramStrings := []namedTypeString{}
+	for _, param := range params {
+		paramStrings = append(paramStrings, namedTypeString{name: param.Name, typeString: param.Type, group: param.group})
+	}
+	signature := fmt.Sprintf("(%s)", joinNamedTypes(paramStrings, ", "))
+
+	if len(results) == 1 && results[0].Name == "" {
+		return fmt.Sprintf("%s %s", signature, results[0].Type)
+	}
+	if len(results) != 0 {
+		resultStrings := []namedTypeString{}
+		for _, result := range results {
+			resultStrings = append(resultStrings, namedTypeString{name: result.Name, typeString: result.Type, group: result.group})
+		}
+		signature = fmt.Sprintf("%s (%s)", signature, joinNamedTypes(resultStrings, ", "))
+	}
+	return signature
+}
+
+//typeParamsString renders the type parameters of a generic declaration, like [K comparable, V any],
+//empty if the declaration is not generic
+func typeParamsString(typeParams []*TypeParam) string {
+	if len(typeParams) == 0 {
+		return ""
+	}
+	declarations := []string{}
+	for _, typeParam := range typeParams {
+		declaration := typeParam.Name
+		if typeParam.Constraint != nil {
+			declaration = fmt.Sprintf("%s %s", declaration, typeParam.Constraint)
+		}
+		declarations = append(declarations, declaration)
+	}
+	return fmt.Sprintf("[%s]", strings.Join(declarations, ", "))
+}
+
+//namedTypeString is the name and the type of a parameter, a result or a struct field
+type namedTypeString struct {
+	name       string
+	typeString string
+	group      int
+}
+
+//joinNamedTypes renders the names with their types, the consecutive names declared together
+//are written with a single type the same way as in the source, a, b int
+func joinNamedTypes(namedTypes []namedTypeString, separator string) string {
+	declarations := []string{}
+	for i := 0; i < len(namedTypes); i++ {
+		names := []string{namedTypes[i].name}
+		for namedTypes[i].name != "" && namedTypes[i].group != 0 && i+1 < len(namedTypes) &&
+			namedTypes[i+1].name != "" && namedTypes[i+1].group == namedTypes[i].group {
+			i++
+			names = append(names, namedTypes[i].name)
+		}
+		declarations = append(declarations, strings.TrimSpace(strings.Join(names, ", ")+" "+namedTypes[i].typeString))
+	}
+	return strings.Join(declarations, separator)
+}
+
+//fieldString renders a struct field, like Name string `json:"name"`
+func fieldString(field *Field) string {
+	theField := strings.TrimSpace(field.Name + " " + field.Type)
+	if field.Embedded {
+		theField = field.Type
+	}
+	if field.Tag != nil {
+		theField = fmt.Sprintf("%s %s", theField, field.Tag.Literal())
+	}
+	return theField
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestTypeExprStringKeepsNameGroups(t *testing.T) {
+	par := mustParseSource(t, `package p
+
+type (
+	Handler   func(a, b int, rest ...string) (n int, err error)
+	Unnamed   func(int, string) error
+	Separated func(a int, b int) (x, y string)
+	Point     struct {
+		X, Y int `+"`json:\"xy\"`"+`
+		Z    float64
+		Named
+	}
+	Named int
+)
+`)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Handler", want: "func(a, b int, rest ...string) (n int, err error)"},
+		{name: "Unnamed", want: "func(int, string) error"},
+		{name: "Separated", want: "func(a int, b int) (x, y string)"},
+	}
+	for _, test := range tests {
+		theType, err := par.GetType(test.name)
+		if err != nil {
+			t.Errorf("GetType(%q) error = %v", test.name, err)
+			continue
+		}
+		if theType.Type != test.want {
+			t.Errorf("GetType(%q).Type = %q, want %q", test.name, theType.Type, test.want)
+		}
+	}
+
+	point, err := par.GetStruct("Point")
+	if err != nil {
+		t.Fatalf("GetStruct(%q) error = %v", "Point", err)
+	}
+	structType := TypeExpr{Kind: StructTypeExpr, Fields: point.Fields}
+	if want := "struct{X, Y int `json:\"xy\"`; Z float64; Named}"; structType.String() != want {
+		t.Errorf("String() = %q, want %q", structType.String(), want)
+	}
+}
@@ -2,7 +2,6 @@ package parser
 
 import (
 	"bytes"
-	"go/token"
 	"text/template"
 )
 
@@ -35,10 +34,13 @@ const (
 	STRUCT
 	FUNCTION
 	METHOD
+	TYPE
+	VARIABLE
+	ENUM
 )
 
 func (gt GolangType) String() string {
-	return [...]string{"interface", "struct", "function", "method"}[gt]
+	return [...]string{"interface", "struct", "function", "method", "type", "variable", "enum"}[gt]
 }
 
 type VariableKind int
@@ -52,35 +54,241 @@ func (vk VariableKind) String() string {
 	return [...]string{"const", "var"}[vk]
 }
 
+type TypeExprKind int
+
+const (
+	NamedTypeExpr TypeExprKind = iota
+	PointerTypeExpr
+	SliceTypeExpr
+	ArrayTypeExpr
+	MapTypeExpr
+	ChanTypeExpr
+	FuncTypeExpr
+	StructTypeExpr
+	InterfaceTypeExpr
+	EllipsisTypeExpr
+	UnionTypeExpr
+)
+
+func (tk TypeExprKind) String() string {
+	return [...]string{"named", "pointer", "slice", "array", "map", "chan", "func", "struct", "interface", "ellipsis", "union"}[tk]
+}
+
+type ChanDir int
+
+const (
+	BothChan ChanDir = iota
+	SendChan
+	RecvChan
+)
+
+func (cd ChanDir) String() string {
+	return [...]string{"both", "send", "recv"}[cd]
+}
+
+//TypeExpr represents a type expression, like *pkg.Type, []map[string][]int or chan<- T,
+//which fields are set depends on the kind
+type TypeExpr struct {
+	Kind TypeExprKind `json:"kind"`
+	// Name of the named type, it's empty for the type literals
+	Name string `json:"name,omitempty"`
+	// Package qualifier of the named type, pkg for pkg.Type
+	Package string `json:"package,omitempty"`
+	// ImportPath of the package the named type comes from, set for the qualified types and the types from the dot imports
+	ImportPath string `json:"importPath,omitempty"`
+	// AmbiguousImportPath is set when the type can come from more than one of the dot imports that are not parsed
+	AmbiguousImportPath bool `json:"ambiguousImportPath,omitempty"`
+	// TypeArgs of an instantiated generic type, int for List[int]
+	TypeArgs []*TypeExpr `json:"typeArgs,omitempty"`
+	// Tilde is set on the type set terms of constraints, like ~int
+	Tilde bool `json:"tilde,omitempty"`
+	// Elem type of the pointer, slice, array, map value, chan and ellipsis
+	Elem *TypeExpr `json:"elem,omitempty"`
+	// Key type of the map
+	Key *TypeExpr `json:"key,omitempty"`
+	// Len of the array as written in the source
+	Len     string  `json:"len,omitempty"`
+	ChanDir ChanDir `json:"chanDir,omitempty"`
+	// Func signature of the func type
+	Func *FuncSignature `json:"func,omitempty"`
+	// Fields of the struct type
+	Fields []*Field `json:"fields,omitempty"`
+	// Methods and Embeds of the interface type
+	Methods []*Method   `json:"methods,omitempty"`
+	Embeds  []*TypeExpr `json:"embeds,omitempty"`
+	// Terms of the type set union, ~int | ~string
+	Terms []*TypeExpr `json:"terms,omitempty"`
+}
+
+type ValueExprKind int
+
+const (
+	BasicLitValueExpr ValueExprKind = iota
+	IdentValueExpr
+	SelectorValueExpr
+	CallValueExpr
+	CompositeValueExpr
+	KeyValueValueExpr
+	UnaryValueExpr
+	BinaryValueExpr
+	IndexValueExpr
+	FuncLitValueExpr
+	OtherValueExpr
+)
+
+func (vk ValueExprKind) String() string {
+	return [...]string{"basicLit", "ident", "selector", "call", "composite", "keyValue", "unary", "binary", "index", "funcLit", "other"}[vk]
+}
+
+//ValueExpr represents a value expression, like "x", time.Second, errors.New("x") or 1 << iota,
+//which fields are set depends on the kind
+type ValueExpr struct {
+	Kind ValueExprKind `json:"kind"`
+	// Source the expression exactly as it's written in the source
+	Source string `json:"source"`
+	// LitKind and Value of the basic literal, INT and 1
+	LitKind string `json:"litKind,omitempty"`
+	Value   string `json:"value,omitempty"`
+	// Name of the identifier or the selected name, Second for time.Second
+	Name string `json:"name,omitempty"`
+	// Package qualifier of the selector, time for time.Second
+	Package string `json:"package,omitempty"`
+	// Op operator of the unary and binary expressions
+	Op string `json:"op,omitempty"`
+	// X and Y the operands of the unary and binary expressions, the key and the value of the key value,
+	// the indexed value and the index of the index expression and the selected value of the selector
+	X *ValueExpr `json:"x,omitempty"`
+	Y *ValueExpr `json:"y,omitempty"`
+	// Func and Args of the call
+	Func *ValueExpr   `json:"func,omitempty"`
+	Args []*ValueExpr `json:"args,omitempty"`
+	// Type of the composite literal and the func literal
+	Type *TypeExpr `json:"type,omitempty"`
+	// Elements of the composite literal
+	Elements []*ValueExpr `json:"elements,omitempty"`
+}
+
+//FuncSignature the parameters and results of a func type
+type FuncSignature struct {
+	Params  []*Parameter `json:"params"`
+	Results []*Result    `json:"results"`
+}
+
 type Interface struct {
-	PackageName string        `json:"packageName"`
+	PackageName string `json:"packageName"`
+	// PackagePath the import path of the package the declaration belongs to
+	PackagePath string        `json:"packagePath"`
 	Doc         *CommentGroup `json:"doc"`
 	Name        string        `json:"name"`
-	Methods     []*Method     `json:"methods"`
+	// Directives the go directives and the annotations of the doc comment, //go:generate, // +gen:mock
+	Directives []Directive  `json:"directives"`
+	TypeParams []*TypeParam `json:"typeParams"`
+	Methods    []*Method    `json:"methods"`
+	// Embeds the embedded interfaces, io.Reader for interface { io.Reader }
+	Embeds []*TypeExpr `json:"embeds"`
+	// TypeSet the type terms of a constraint interface, like ~int | ~string
+	TypeSet []*TypeExpr `json:"typeSet"`
+	Pos     Position    `json:"pos"`
+	End     Position    `json:"end"`
+	// file the interface is declared in, used to resolve the embedded interfaces
+	file *parserGoFile
 }
 
+//Variable represents a single name of a var or const spec, var a, b = 1, 2 is represented as two variables
 type Variable struct {
 	PackageName string `json:"packageName"`
+	// PackagePath the import path of the package the declaration belongs to
+	PackagePath string `json:"packagePath"`
 	Doc         *CommentGroup
-	Kind        VariableKind `json:"kind"`
-	Name        string       `json:"name"`
-	Value       *string      `json:"value"`
+	// Comment the trailing comment on the line of the spec, A = 1 // the first
+	Comment *CommentGroup `json:"comment"`
+	// Directives the directives and the annotations from the doc and the trailing comment
+	Directives []Directive  `json:"directives"`
+	Kind       VariableKind `json:"kind"`
+	Name       string       `json:"name"`
+	// Names all the names declared in the same spec, a and b for var a, b = 1, 2
+	Names []string `json:"names"`
+	// Type the explicitly declared type, the constants converted into a package type get it, Status for Status(iota),
+	// empty if the type is not declared
+	Type     string    `json:"type"`
+	TypeExpr *TypeExpr `json:"typeExpr"`
+	// ResolvedType the type of the variable with the packages qualified by their import path, the type is
+	// inferred from the value when it's not declared, only set when the packages are type checked
+	ResolvedType string `json:"resolvedType,omitempty"`
+	// UnderlyingType the underlying type of the resolved type, string for type Status string
+	UnderlyingType string `json:"underlyingType,omitempty"`
+	// Value the source text of the value, nil if there is no value,
+	// when multiple names are assigned from a single call all of them have the call as the value
+	Value     *string    `json:"value"`
+	ValueExpr *ValueExpr `json:"valueExpr"`
+	// ConstValue the evaluated value of the constant, string constants are unquoted,
+	// nil for variables and for constants that depend on declarations outside of the package
+	ConstValue *string `json:"constValue"`
+	// ConstKind the kind of the evaluated value, bool, string, int, float or complex
+	ConstKind string `json:"constKind"`
+	// Iota the index of the spec inside the const group, the value of iota for the constant
+	Iota int      `json:"iota"`
+	Pos  Position `json:"pos"`
+	End  Position `json:"end"`
+}
+
+//Enum is a named type together with the constants declared with that type
+type Enum struct {
+	PackageName string `json:"packageName"`
+	// PackagePath the import path of the package the declaration belongs to
+	PackagePath string    `json:"packagePath"`
+	Name        string    `json:"name"`
+	Type        *TypeDecl `json:"type"`
+	// Members the constants of the type in the order they are declared
+	Members []Variable `json:"members"`
 }
 
 type Import struct {
 	PackageName string        `json:"packageName"`
 	Doc         *CommentGroup `json:"doc"`
 	Name        *string       `json:"name"`
-	Path        string        `json:"path"`
-	Comment     *CommentGroup `json:"comment"`
+	// Path the unquoted import path
+	Path string `json:"path"`
+	// LocalName the name the package is referred to with inside the file, the name of the import,
+	// or the package name when the import is not named
+	LocalName string        `json:"localName"`
+	Comment   *CommentGroup `json:"comment"`
+	Pos       Position      `json:"pos"`
+	End       Position      `json:"end"`
 }
 
 type Struct struct {
-	PackageName string        `json:"packageName"`
+	PackageName string `json:"packageName"`
+	// PackagePath the import path of the package the declaration belongs to
+	PackagePath string        `json:"packagePath"`
 	Doc         *CommentGroup `json:"doc"`
 	Name        string        `json:"name"`
+	Directives  []Directive   `json:"directives"`
+	TypeParams  []*TypeParam  `json:"typeParams"`
 	Fields      []*Field      `json:"fields"`
 	Methods     []*Method     `json:"methods"`
+	Pos         Position      `json:"pos"`
+	End         Position      `json:"end"`
+	// file the struct is declared in, used to resolve the embedded fields
+	file *parserGoFile
+}
+
+//TypeDecl represents a named type that is not a struct or an interface,
+//like type Status string, or a type alias like type A = B
+type TypeDecl struct {
+	PackageName string `json:"packageName"`
+	// PackagePath the import path of the package the declaration belongs to
+	PackagePath string        `json:"packagePath"`
+	Doc         *CommentGroup `json:"doc"`
+	Name        string        `json:"name"`
+	Directives  []Directive   `json:"directives"`
+	TypeParams  []*TypeParam  `json:"typeParams"`
+	Type        string        `json:"type"`
+	TypeExpr    *TypeExpr     `json:"typeExpr"`
+	IsAlias     bool          `json:"isAlias"`
+	Methods     []*Method     `json:"methods"`
+	Pos         Position      `json:"pos"`
+	End         Position      `json:"end"`
 }
 
 const (
@@ -89,6 +297,12 @@ const (
 	ImportTemplate    string = "ImportTemplate"
 )
 
+//templateFuncs the functions available to the declaration templates
+var templateFuncs = template.FuncMap{
+	"signature":  signatureString,
+	"typeParams": typeParamsString,
+}
+
 func (s Struct) String() (string, error) {
 	declarationString, err := generateTypeDeclaration(StructTemplate, StructTemplateString, s)
 	if err != nil {
@@ -116,7 +330,7 @@ func (i Import) String() (string, error) {
 }
 
 func generateTypeDeclaration(templateName string, templateString string, object interface{}) (declarationString string, err error) {
-	tmpl, err := template.New(templateName).Parse(templateString)
+	tmpl, err := template.New(templateName).Funcs(templateFuncs).Parse(templateString)
 	if err != nil {
 		return declarationString, err
 	}
@@ -135,75 +349,215 @@ func generateTypeDeclaration(templateName string, templateString string, object
 // Interface it represents the method list
 // Declaration signature it represents parameters/results
 type Field struct {
-	Doc           *CommentGroup `json:"doc"`
-	Name          string        `json:"name"`
-	IsTypePointer bool          `json:"isTypePointer"`
-	Type          string        `json:"type"`
-	Tag           *Tag          `json:"tag"`
+	Doc *CommentGroup `json:"doc"`
+	// Comment the trailing comment on the line of the field, Name string // the name
+	Comment *CommentGroup `json:"comment"`
+	// Directives the directives and the annotations from the doc and the trailing comment, // +optional
+	Directives []Directive `json:"directives"`
+	// Name the name of the field, for embedded fields it's the name of the type without the package and the pointer
+	Name string `json:"name"`
+	// Embedded the field is declared only with the type, struct { Base; *sync.Mutex }
+	Embedded      bool      `json:"embedded"`
+	IsTypePointer bool      `json:"isTypePointer"`
+	Type          string    `json:"type"`
+	TypeExpr      *TypeExpr `json:"typeExpr"`
+	// ResolvedType the type with the packages qualified by their import path, github.com/acme/db.Conn,
+	// only set when the packages are type checked
+	ResolvedType string `json:"resolvedType,omitempty"`
+	// UnderlyingType the underlying type of the resolved type, string for type Status string
+	UnderlyingType string   `json:"underlyingType,omitempty"`
+	Tag            *Tag     `json:"tag"`
+	Pos            Position `json:"pos"`
+	End            Position `json:"end"`
+	// group the declaration the name comes from, starting at 1, the names of a group share the type, a, b int
+	group int
 }
 
-type TagValue struct {
-	Type  string `json:"type"`
-	Value string `json:"value"`
+//Implementation is a type that implements an interface, the pointer to the type always implements
+//the interfaces the value implements
+type Implementation struct {
+	PackageName string `json:"packageName"`
+	// PackagePath the import path of the package the type is declared in
+	PackagePath string     `json:"packagePath"`
+	TypeName    string     `json:"typeName"`
+	Interface   *Interface `json:"interface"`
+	// Value the value of the type implements the interface, T
+	Value bool `json:"value"`
+	// Pointer the pointer to the type implements the interface, *T
+	Pointer bool `json:"pointer"`
+}
+
+//PromotedField is a field of an embedded field that can be selected directly on the struct
+type PromotedField struct {
+	*Field
+	// Path the names of the embedded fields the field is promoted through, [Base] for s.Base.ID
+	Path []string `json:"path"`
+	// Indirect at least one of the embedded fields on the path is a pointer
+	Indirect bool `json:"indirect"`
 }
 
+//PromotedMethod is a method of an embedded field that can be called directly on the struct
+type PromotedMethod struct {
+	*Method
+	// Path the names of the embedded fields the method is promoted through, [Base] for s.Base.Save()
+	Path []string `json:"path"`
+	// Indirect at least one of the embedded fields on the path is a pointer, so the methods with
+	// the pointer receiver are callable on the struct value too
+	Indirect bool `json:"indirect"`
+}
+
+//Tag represents a struct field tag, parsed following the reflect.StructTag conventions
 type Tag struct {
-	Type  token.Token `json:"type"`
-	Value TagValue    `json:"value"`
+	// Raw the tag without the surrounding quotes or backticks
+	Raw string `json:"raw"`
+	// Keys in the order they are written in the tag
+	Keys []*TagKey `json:"keys"`
+}
+
+//TagKey represents a single key of the struct tag, json:"name,omitempty"
+type TagKey struct {
+	Key string `json:"key"`
+	// Value the unquoted value of the key, name,omitempty
+	Value string `json:"value"`
+	// Name the first comma separated part of the value, name
+	Name string `json:"name"`
+	// Options the rest of the comma separated parts of the value, omitempty
+	Options []string `json:"options"`
 }
 
 type Receiver struct {
 	PointerReceiver bool   `json:"pointerReceiver"`
 	Type            string `json:"type"`
-	Name            string `json:"name"`
+	// BaseType name of the type the method belongs to, without the pointer and type parameters
+	BaseType string   `json:"baseType"`
+	Name     string   `json:"name"`
+	Pos      Position `json:"pos"`
+	End      Position `json:"end"`
 }
 
 //Method represents a struct or interface method
 type Method struct {
-	Doc      *CommentGroup `json:"doc"`
-	Receiver *Receiver     `json:"receiver"`
-	Name     string        `json:"name"`
-	Params   []*Parameter  `json:"params"`
-	Results  []*Result     `json:"results"`
+	PackageName string        `json:"packageName"`
+	Doc         *CommentGroup `json:"doc"`
+	// Comment the trailing comment of the interface method, it's always nil for the declared methods
+	Comment    *CommentGroup `json:"comment"`
+	Receiver   *Receiver     `json:"receiver"`
+	Name       string        `json:"name"`
+	Directives []Directive   `json:"directives"`
+	// TypeParams of the receiver type, named as in the receiver, T for func (l *List[T])
+	TypeParams []*TypeParam `json:"typeParams"`
+	Params     []*Parameter `json:"params"`
+	Results    []*Result    `json:"results"`
+	Pos        Position     `json:"pos"`
+	End        Position     `json:"end"`
+	// file the method is declared in, used to resolve the types of the signature
+	file *parserGoFile
 }
 
 type Package struct {
-	Name          string   `json:"name"`
+	Name string `json:"name"`
+	// Doc the package doc comment, from doc.go if it has one or from the first file with the package doc
+	Doc *CommentGroup `json:"doc"`
+	// ImportPath the module path from the nearest go.mod joined with the directory relative to it,
+	// empty if there is no go.mod
+	ImportPath    string   `json:"importPath"`
 	Files         []GoFile `json:"files"`
 	DirectoryPath string   `json:"directoryPath"`
 }
 
 type GoFile struct {
-	Structs    []*Struct    `json:"structs"`
-	Interfaces []*Interface `json:"interfaces"`
-	Imports    []*Import    `json:"imports"`
-	Variables  []Variable   `json:"variables"`
-	Functions  []*Function  `json:"functions"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// BuildConstraint the build constraint expression of the file, like linux && amd64,
+	// the legacy // +build lines are joined into one expression, empty if the file has no constraints
+	BuildConstraint string `json:"buildConstraint"`
+	// GOOS the operating system from the file name suffix, linux for file_linux.go, empty without the suffix
+	GOOS string `json:"goos"`
+	// GOARCH the architecture from the file name suffix, amd64 for file_linux_amd64.go, empty without the suffix
+	GOARCH string `json:"goarch"`
+	// Directives the directives that don't belong to any declaration, like //go:build and //go:generate
+	Directives []Directive `json:"directives"`
+	// HeaderComments the comments above the package clause that are not the package doc, like license headers
+	HeaderComments []*CommentGroup `json:"headerComments"`
+	// FloatingComments the comments between the declarations that don't belong to any declaration,
+	// the comments inside the function bodies are not included
+	FloatingComments []*CommentGroup `json:"floatingComments"`
+	Structs          []*Struct       `json:"structs"`
+	Interfaces       []*Interface    `json:"interfaces"`
+	Imports          []*Import       `json:"imports"`
+	Variables        []Variable      `json:"variables"`
+	Functions        []*Function     `json:"functions"`
+	Types            []*TypeDecl     `json:"types"`
 }
 
 // TODO: anonimous functions
 
 //Function represents a function
 type Function struct {
-	PackageName string        `json:"packageName"`
+	PackageName string `json:"packageName"`
+	// PackagePath the import path of the package the declaration belongs to
+	PackagePath string        `json:"packagePath"`
 	Doc         *CommentGroup `json:"doc"`
 	Name        string        `json:"name"`
+	Directives  []Directive   `json:"directives"`
+	TypeParams  []*TypeParam  `json:"typeParams"`
 	Params      []*Parameter  `json:"params"`
 	Results     []*Result     `json:"results"`
+	Pos         Position      `json:"pos"`
+	End         Position      `json:"end"`
+}
+
+//TypeParam a type parameter of a generic declaration and its constraint, T any
+type TypeParam struct {
+	Name       string    `json:"name"`
+	Constraint *TypeExpr `json:"constraint"`
+	Pos        Position  `json:"pos"`
+	End        Position  `json:"end"`
 }
 
 //Parameter a variable that is passed into a method/function
 type Parameter struct {
-	Name          string `json:"name"`
-	IsTypePointer bool   `json:"isTypePointer"`
-	Type          string `json:"type"`
+	Name          string    `json:"name"`
+	IsTypePointer bool      `json:"isTypePointer"`
+	Type          string    `json:"type"`
+	TypeExpr      *TypeExpr `json:"typeExpr"`
+	// Variadic the parameter is declared with ...T, the Type is ...T as well
+	Variadic bool `json:"variadic"`
+	// ResolvedType the type with the packages qualified by their import path, github.com/acme/db.Conn,
+	// only set when the packages are type checked
+	ResolvedType string `json:"resolvedType,omitempty"`
+	// UnderlyingType the underlying type of the resolved type, string for type Status string
+	UnderlyingType string   `json:"underlyingType,omitempty"`
+	Pos            Position `json:"pos"`
+	End            Position `json:"end"`
+	// group the declaration the name comes from, starting at 1, the names of a group share the type, a, b int
+	group int
 }
 
 //Result result is a variable returned from a function or method
 type Result struct {
-	Name          string `json:"name"`
-	IsTypePointer bool   `json:"isTypePointer"`
-	Type          string `json:"type"`
+	Name          string    `json:"name"`
+	IsTypePointer bool      `json:"isTypePointer"`
+	Type          string    `json:"type"`
+	TypeExpr      *TypeExpr `json:"typeExpr"`
+	// ResolvedType the type with the packages qualified by their import path, github.com/acme/db.Conn,
+	// only set when the packages are type checked
+	ResolvedType string `json:"resolvedType,omitempty"`
+	// UnderlyingType the underlying type of the resolved type, string for type Status string
+	UnderlyingType string   `json:"underlyingType,omitempty"`
+	Pos            Position `json:"pos"`
+	End            Position `json:"end"`
+	// group the declaration the name comes from, starting at 1, the names of a group share the type, a, b int
+	group int
+}
+
+//Position a position in the source file, the offset is in bytes and starts at 0,
+//the line and the column start at 1
+type Position struct {
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Offset   int    `json:"offset"`
 }
 
 //Comment represents a single line of a comment
@@ -215,4 +569,94 @@ type Comment struct {
 type CommentGroup struct {
 	PackageName string     `json:"packageName"`
 	Comments    []*Comment `json:"comments"`
+	// Pos and End of the comments, empty for the empty comment groups
+	Pos Position `json:"pos"`
+	End Position `json:"end"`
+	// file the comments are in, used to resolve the doc links
+	file *parserGoFile
+}
+
+//Directive is a comment recognized by one of the directive syntaxes, like //go:generate or // @route GET /users
+type Directive struct {
+	// Name of the directive without the markers, go:generate, nolint, route
+	Name string   `json:"name"`
+	Args []string `json:"args"`
+	// Raw the whole comment the directive was parsed from
+	Raw string   `json:"raw"`
+	Pos Position `json:"pos"`
+}
+
+type DocBlockKind int
+
+const (
+	ParagraphDocBlock DocBlockKind = iota
+	HeadingDocBlock
+	ListDocBlock
+	CodeDocBlock
+)
+
+func (dk DocBlockKind) String() string {
+	return [...]string{"paragraph", "heading", "list", "code"}[dk]
+}
+
+type DocTextKind int
+
+const (
+	PlainDocText DocTextKind = iota
+	ItalicDocText
+	LinkDocText
+	DocLinkDocText
+)
+
+func (dk DocTextKind) String() string {
+	return [...]string{"plain", "italic", "link", "docLink"}[dk]
+}
+
+//DocComment is a comment group parsed following the go doc comment syntax
+type DocComment struct {
+	Blocks []*DocBlock `json:"blocks"`
+	// Links the link definitions, [Text]: URL
+	Links []*DocLinkDef `json:"links"`
+}
+
+//DocBlock is a paragraph, heading, list or code block of the doc comment
+type DocBlock struct {
+	Kind DocBlockKind `json:"kind"`
+	// Text of the paragraph or the heading
+	Text []*DocText `json:"text,omitempty"`
+	// Items of the list
+	Items []*DocListItem `json:"items,omitempty"`
+	// Code of the code block without the indentation
+	Code string `json:"code,omitempty"`
+}
+
+type DocListItem struct {
+	// Number of the numbered list item, empty for the bullet list items
+	Number  string      `json:"number"`
+	Content []*DocBlock `json:"content"`
+}
+
+//DocText is a span of text inside a paragraph, heading or link
+type DocText struct {
+	Kind DocTextKind `json:"kind"`
+	// Text of the plain and italic text
+	Text string `json:"text,omitempty"`
+	// Content the text of the link and the doc link
+	Content []*DocText `json:"content,omitempty"`
+	// URL of the link
+	URL string `json:"url,omitempty"`
+	// ImportPath, Recv and Name are the target of the doc link, [pkg.Recv.Name],
+	// the links to the current package have the import path of the current package
+	ImportPath string `json:"importPath,omitempty"`
+	Recv       string `json:"recv,omitempty"`
+	Name       string `json:"name,omitempty"`
+	// Resolved the target of the doc link is declared in the parsed packages
+	Resolved bool `json:"resolved,omitempty"`
+}
+
+type DocLinkDef struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+	// Used the link definition is used by at least one link in the doc comment
+	Used bool `json:"used"`
 }
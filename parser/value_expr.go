@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+//convertExpressionIntoValueExpr takes in an ast value expression and returns this libraries representation of the value,
+//every valid expression is converted, the ones that are not modeled only have the kind other and the source
+func convertExpressionIntoValueExpr(file parserGoFile, expression ast.Expr) *ValueExpr {
+	valueExpr := &ValueExpr{Source: parseSourceText(file, expression)}
+
+	switch expression := expression.(type) {
+	case *ast.BasicLit:
+		valueExpr.Kind = BasicLitValueExpr
+		valueExpr.LitKind = expression.Kind.String()
+		valueExpr.Value = expression.Value
+	case *ast.Ident:
+		valueExpr.Kind = IdentValueExpr
+		valueExpr.Name = expression.Name
+	case *ast.SelectorExpr:
+		valueExpr.Kind = SelectorValueExpr
+		valueExpr.Name = expression.Sel.Name
+		// pkg.Name has the qualifier, for the other selectors the selected expression is kept, a.b.Name
+		if packageIdent, ok := expression.X.(*ast.Ident); ok {
+			valueExpr.Package = packageIdent.Name
+		} else {
+			valueExpr.X = convertExpressionIntoValueExpr(file, expression.X)
+		}
+	case *ast.ParenExpr:
+		inner := convertExpressionIntoValueExpr(file, expression.X)
+		inner.Source = valueExpr.Source
+		return inner
+	case *ast.CallExpr:
+		valueExpr.Kind = CallValueExpr
+		valueExpr.Func = convertExpressionIntoValueExpr(file, expression.Fun)
+		for _, arg := range expression.Args {
+			valueExpr.Args = append(valueExpr.Args, convertExpressionIntoValueExpr(file, arg))
+		}
+	case *ast.CompositeLit:
+		valueExpr.Kind = CompositeValueExpr
+		if expression.Type != nil {
+			// the type is only informational, the value is still modeled if it can't be converted
+			valueExpr.Type, _ = convertExpressionIntoTypeExpr(file, expression.Type)
+		}
+		for _, element := range expression.Elts {
+			valueExpr.Elements = append(valueExpr.Elements, convertExpressionIntoValueExpr(file, element))
+		}
+	case *ast.KeyValueExpr:
+		valueExpr.Kind = KeyValueValueExpr
+		valueExpr.X = convertExpressionIntoValueExpr(file, expression.Key)
+		valueExpr.Y = convertExpressionIntoValueExpr(file, expression.Value)
+	case *ast.UnaryExpr:
+		valueExpr.Kind = UnaryValueExpr
+		valueExpr.Op = expression.Op.String()
+		valueExpr.X = convertExpressionIntoValueExpr(file, expression.X)
+	case *ast.StarExpr:
+		valueExpr.Kind = UnaryValueExpr
+		valueExpr.Op = "*"
+		valueExpr.X = convertExpressionIntoValueExpr(file, expression.X)
+	case *ast.BinaryExpr:
+		valueExpr.Kind = BinaryValueExpr
+		valueExpr.Op = expression.Op.String()
+		valueExpr.X = convertExpressionIntoValueExpr(file, expression.X)
+		valueExpr.Y = convertExpressionIntoValueExpr(file, expression.Y)
+	case *ast.IndexExpr:
+		valueExpr.Kind = IndexValueExpr
+		valueExpr.X = convertExpressionIntoValueExpr(file, expression.X)
+		valueExpr.Y = convertExpressionIntoValueExpr(file, expression.Index)
+	case *ast.FuncLit:
+		valueExpr.Kind = FuncLitValueExpr
+		valueExpr.Type, _ = convertExpressionIntoTypeExpr(file, expression.Type)
+	default:
+		valueExpr.Kind = OtherValueExpr
+	}
+	return valueExpr
+}
+
+//parseSourceText returns the source of the node exactly as it's written in the file
+func parseSourceText(file parserGoFile, node ast.Node) string {
+	if file.Src != nil && file.Fset != nil {
+		start := file.Fset.Position(node.Pos()).Offset
+		end := file.Fset.Position(node.End()).Offset
+		if start >= 0 && start <= end && end <= len(file.Src) {
+			return string(file.Src[start:end])
+		}
+	}
+
+	if expression, ok := node.(ast.Expr); ok {
+		return types.ExprString(expression)
+	}
+	return ""
+}
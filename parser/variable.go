@@ -1,7 +1,6 @@
 package parser
 
 import (
-	"errors"
 	"go/ast"
 	"go/token"
 )
@@ -9,7 +8,7 @@ import (
 func getVariables(file parserGoFile) (variables []Variable, err error) {
 	genDecls := parseGenDeclarations(file)
 	variableGenDecls := parseVariableDecls(genDecls)
-	theVars, err := convertGenDeclsIntoVariable(1, variableGenDecls)
+	theVars, err := convertGenDeclsIntoVariable(file, Var, variableGenDecls)
 	if err != nil {
 		return nil, err
 	}
@@ -18,6 +17,28 @@ func getVariables(file parserGoFile) (variables []Variable, err error) {
 	return variables, nil
 }
 
+//getVariablesAndConstants returns both the variables and the constants in the order they are declared
+func getVariablesAndConstants(file parserGoFile) (variables []Variable, err error) {
+	for _, genDecl := range parseGenDeclarations(file) {
+		kind := Var
+		switch genDecl.Tok {
+		case token.VAR:
+			kind = Var
+		case token.CONST:
+			kind = Const
+		default:
+			continue
+		}
+
+		vars, err := convertGenDeclsIntoVariable(file, kind, []*ast.GenDecl{genDecl})
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, vars...)
+	}
+	return variables, nil
+}
+
 //parseFunctionDecls returns only variable declarations from the provided declarations
 func parseVariableDecls(genDecls []*ast.GenDecl) (valueSpecs []*ast.GenDecl) {
 	for _, genDecl := range genDecls {
@@ -40,92 +61,123 @@ func parseConstDecls(genDecls []*ast.GenDecl) (valueSpecs []*ast.GenDecl) {
 	return valueSpecs
 }
 
-//singleSpecVariableDeclarationConversion this is when there is just a single
-// variable per var keyword
-func singleSpecVariableDeclarationConversion(kind VariableKind, genDecl *ast.GenDecl) (variables []Variable, err error) {
-	variable := Variable{}
-	commentGroup, err := parseComments(genDecl)
-	if err != nil {
-		return variables, err
-	}
-	variable.Doc = commentGroup
-	variable.Kind = kind
-	variable.Name = genDecl.Specs[0].(*ast.ValueSpec).Names[0].Name
-	if len(genDecl.Specs[0].(*ast.ValueSpec).Values) == 0 {
-		variable.Value = nil
-	} else {
-		theType := genDecl.Specs[0].(*ast.ValueSpec).Values[0]
-		switch theType.(type) {
-		case (*ast.UnaryExpr):
-			value := genDecl.Specs[0].(*ast.ValueSpec).Values[0].(*ast.UnaryExpr).Op.String() + genDecl.Specs[0].(*ast.ValueSpec).Values[0].(*ast.UnaryExpr).X.(*ast.Ident).Name
-			variable.Value = &value
-		case (*ast.BasicLit):
-			variable.Value = &genDecl.Specs[0].(*ast.ValueSpec).Values[0].(*ast.BasicLit).Value
-		case (*ast.CompositeLit):
-			variable.Value = &genDecl.Specs[0].(*ast.ValueSpec).Values[0].(*ast.CompositeLit).Type.(*ast.Ident).Name
-		default:
-			return nil, errors.New("unsuppored variable type")
+func convertGenDeclsIntoVariable(file parserGoFile, kind VariableKind, genDecls []*ast.GenDecl) (variables []Variable, err error) {
+	for _, genDecl := range genDecls {
+		for _, spec := range genDecl.Specs {
+			vars, err := convertValueSpecIntoVariables(file, kind, genDecl, spec.(*ast.ValueSpec))
+			if err != nil {
+				return nil, err
+			}
+			variables = append(variables, vars...)
 		}
 	}
-	variables = append(variables, variable)
 	return variables, nil
 }
 
-//multiSpecVariableDeclarationConversion this takes care of the scenario
-// where there is multiple variables inside a single var keyword
-func multiSpecVariableDeclarationConversion(kind VariableKind, genDecl *ast.GenDecl) (variables []Variable, err error) {
-	for _, spec := range genDecl.Specs {
-		variable := Variable{}
-		commentGroup, err := parseSpecComments(spec)
+//convertValueSpecIntoVariables converts the value spec into a variable for every name in the spec
+func convertValueSpecIntoVariables(file parserGoFile, kind VariableKind, genDecl *ast.GenDecl, valueSpec *ast.ValueSpec) (
+	variables []Variable, err error) {
+
+	// a single spec without parentheses has the doc on the general declaration
+	commentGroup, err := parseSpecComments(file, valueSpec)
+	if err != nil {
+		return nil, err
+	}
+	if valueSpec.Doc == nil && !genDecl.Lparen.IsValid() {
+		commentGroup, err = parseComments(file, genDecl)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	directives := parseDirectives(file, valueSpec.Doc, valueSpec.Comment)
+	if valueSpec.Doc == nil && !genDecl.Lparen.IsValid() {
+		directives = parseDirectives(file, genDecl.Doc, valueSpec.Comment)
+	}
+
+	var typeExpr *TypeExpr
+	if valueSpec.Type != nil {
+		typeExpr, err = convertExpressionIntoTypeExpr(file, valueSpec.Type)
 		if err != nil {
-			return variables, err
+			return nil, err
 		}
+	}
+
+	names := []string{}
+	for _, name := range valueSpec.Names {
+		names = append(names, name.Name)
+	}
+
+	for i, name := range valueSpec.Names {
+		variable := Variable{}
+		variable.PackageName = getPackageName(file)
+		variable.PackagePath = getPackagePath(file)
 		variable.Doc = commentGroup
+		variable.Directives = directives
+		if valueSpec.Comment != nil {
+			variable.Comment = convertCommentGroup(file, valueSpec.Comment)
+		}
 		variable.Kind = kind
-		variable.Name = spec.(*ast.ValueSpec).Names[0].Name
-		if len(genDecl.Specs[0].(*ast.ValueSpec).Values) == 0 {
-			variable.Value = nil
-		} else {
-			theType := spec.(*ast.ValueSpec).Values[0]
-			switch theType.(type) {
-			case (*ast.UnaryExpr):
-				theValue := spec.(*ast.ValueSpec).Values[0].(*ast.UnaryExpr).Op.String() + spec.(*ast.ValueSpec).Values[0].(*ast.UnaryExpr).X.(*ast.Ident).Name
-				variable.Value = &theValue
-			case (*ast.BasicLit):
-				variable.Value = &spec.(*ast.ValueSpec).Values[0].(*ast.BasicLit).Value
-			case (*ast.CompositeLit):
-				variable.Value = &spec.(*ast.ValueSpec).Values[0].(*ast.CompositeLit).Type.(*ast.Ident).Name
-			default:
-				panic("unsupported type: convertGenDeclsIntoVariable")
+		variable.Name = name.Name
+		variable.Names = names
+		variable.Pos, variable.End = parseSpecRange(file, genDecl, valueSpec)
+		variable.ResolvedType, variable.UnderlyingType = resolveIdentType(file, name)
+		if typeExpr != nil {
+			variable.Type = typeExpr.String()
+			variable.TypeExpr = typeExpr
+		}
+
+		// var a, b = f() assigns all the names from a single value
+		var valueExpression ast.Expr
+		if len(valueSpec.Values) == len(valueSpec.Names) {
+			valueExpression = valueSpec.Values[i]
+		} else if len(valueSpec.Values) == 1 {
+			valueExpression = valueSpec.Values[0]
+		}
+		if valueExpression != nil {
+			variable.ValueExpr = convertExpressionIntoValueExpr(file, valueExpression)
+			value := variable.ValueExpr.Source
+			variable.Value = &value
+		}
+
+		if kind == Const {
+			err = setConstantValue(file, name, &variable)
+			if err != nil {
+				return nil, err
 			}
 		}
+
 		variables = append(variables, variable)
 	}
 	return variables, nil
 }
 
-func convertGenDeclsIntoVariable(kind VariableKind, genDecls []*ast.GenDecl) (variables []Variable, err error) {
-	for _, genDecl := range genDecls {
-		if len(genDecl.Specs) == 1 {
-			vars, err := singleSpecVariableDeclarationConversion(kind, genDecl)
-			if err != nil {
-				return variables, nil
-			}
-			variables = append(variables, vars...)
-		} else {
-			vars, err := multiSpecVariableDeclarationConversion(kind, genDecl)
-			if err != nil {
-				return variables, err
-			}
-			variables = append(variables, vars...)
+//setConstantValue sets the evaluated value and the iota of the constant, a constant with the
+//value omitted gets the type repeated from the previous spec of the const group
+func setConstantValue(file parserGoFile, name *ast.Ident, variable *Variable) error {
+	if file.Package == nil {
+		return nil
+	}
+	theConstant, ok := file.Package.ConstantsByIdent[name]
+	if !ok {
+		return nil
+	}
+
+	variable.Iota = theConstant.Iota
+	variable.ConstValue, variable.ConstKind = formatConstantValue(evaluateConstant(file.Package, theConstant))
+	if variable.TypeExpr == nil && theConstant.Type != nil {
+		typeExpr, err := convertExpressionIntoTypeExpr(file, theConstant.Type)
+		if err != nil {
+			return err
 		}
+		variable.Type = typeExpr.String()
+		variable.TypeExpr = typeExpr
 	}
-	return variables, nil
+	return nil
 }
 
-func parseSpecComments(spec ast.Spec) (*CommentGroup, error) {
+func parseSpecComments(file parserGoFile, spec ast.Spec) (*CommentGroup, error) {
 	astCommentGroup := &ast.CommentGroup{}
-	commentGroup := &CommentGroup{}
 
 	switch spec := spec.(type) {
 	case *ast.ValueSpec:
@@ -136,15 +188,5 @@ func parseSpecComments(spec ast.Spec) (*CommentGroup, error) {
 		astCommentGroup = spec.Doc
 	}
 
-	if astCommentGroup == nil {
-		commentGroup = &CommentGroup{}
-		return commentGroup, nil
-	}
-	for _, astComment := range astCommentGroup.List {
-		comment := Comment{}
-		comment.Text = astComment.Text
-		commentGroup.Comments = append(commentGroup.Comments, &comment)
-	}
-
-	return commentGroup, nil
+	return convertCommentGroup(file, astCommentGroup), nil
 }
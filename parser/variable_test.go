@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const variablesSource = `package p
+
+import (
+	"os"
+	"time"
+)
+
+var Timeout = 5 * time.Second
+
+var a, b = 1, "two"
+
+var reader, readErr = os.Open("x")
+
+var (
+	Counter int
+	Names   = []string{"a"}
+)
+
+const Prefix string = "p_"
+`
+
+func TestGetVariable(t *testing.T) {
+	par := mustParseSource(t, variablesSource)
+
+	tests := []struct {
+		name          string
+		wantKind      VariableKind
+		wantNames     []string
+		wantType      string
+		wantValue     string
+		wantValueKind ValueExprKind
+	}{
+		{name: "Timeout", wantKind: Var, wantNames: []string{"Timeout"}, wantValue: "5 * time.Second", wantValueKind: BinaryValueExpr},
+		{name: "a", wantKind: Var, wantNames: []string{"a", "b"}, wantValue: "1", wantValueKind: BasicLitValueExpr},
+		{name: "b", wantKind: Var, wantNames: []string{"a", "b"}, wantValue: `"two"`, wantValueKind: BasicLitValueExpr},
+		{name: "reader", wantKind: Var, wantNames: []string{"reader", "readErr"}, wantValue: `os.Open("x")`, wantValueKind: CallValueExpr},
+		{name: "readErr", wantKind: Var, wantNames: []string{"reader", "readErr"}, wantValue: `os.Open("x")`, wantValueKind: CallValueExpr},
+		{name: "Counter", wantKind: Var, wantNames: []string{"Counter"}, wantType: "int"},
+		{name: "Names", wantKind: Var, wantNames: []string{"Names"}, wantValue: `[]string{"a"}`, wantValueKind: CompositeValueExpr},
+		{name: "Prefix", wantKind: Const, wantNames: []string{"Prefix"}, wantType: "string", wantValue: `"p_"`, wantValueKind: BasicLitValueExpr},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			variable, err := par.GetVariable(test.name)
+			if err != nil {
+				t.Fatalf("GetVariable(%q) error = %v", test.name, err)
+			}
+			if variable.Kind != test.wantKind {
+				t.Errorf("GetVariable(%q).Kind = %s, want %s", test.name, variable.Kind, test.wantKind)
+			}
+			if !reflect.DeepEqual(variable.Names, test.wantNames) {
+				t.Errorf("GetVariable(%q).Names = %v, want %v", test.name, variable.Names, test.wantNames)
+			}
+			if variable.Type != test.wantType {
+				t.Errorf("GetVariable(%q).Type = %q, want %q", test.name, variable.Type, test.wantType)
+			}
+			if test.wantValue == "" {
+				if variable.Value != nil {
+					t.Errorf("GetVariable(%q).Value = %q, want nil", test.name, *variable.Value)
+				}
+				return
+			}
+			if variable.Value == nil || *variable.Value != test.wantValue {
+				t.Fatalf("GetVariable(%q).Value = %v, want %q", test.name, variable.Value, test.wantValue)
+			}
+			if variable.ValueExpr == nil || variable.ValueExpr.Kind != test.wantValueKind {
+				t.Errorf("GetVariable(%q).ValueExpr = %+v, want kind %s", test.name, variable.ValueExpr, test.wantValueKind)
+			}
+		})
+	}
+}
+
+func TestGetVariablesByNames(t *testing.T) {
+	par := mustParseSource(t, variablesSource)
+
+	variables, err := par.GetVariablesByNames("Prefix", "p.Timeout", "b")
+	if err != nil {
+		t.Fatalf("GetVariablesByNames() error = %v", err)
+	}
+	names := []string{}
+	for _, variable := range variables {
+		names = append(names, variable.Name)
+	}
+	if want := []string{"Prefix", "Timeout", "b"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("GetVariablesByNames() = %v, want %v", names, want)
+	}
+
+	_, err = par.GetVariablesByNames("Prefix", "Missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetVariablesByNames() with a missing name error = %v, want ErrNotFound", err)
+	}
+	_, err = par.GetVariable("other.Prefix")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetVariable() from another package error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,187 @@
+package parser
+
+import (
+	"sync"
+)
+
+//builtinImportPath the import path the predeclared types are looked up with, error is the only predeclared interface
+const builtinImportPath = "builtin"
+
+//wellKnownSources the declarations of the commonly embedded standard library interfaces by their import path,
+//they are used when the packages are not parsed
+var wellKnownSources = map[string]string{
+	builtinImportPath: `package builtin
+
+type error interface {
+	Error() string
+}
+`,
+	"fmt": `package fmt
+
+type Stringer interface {
+	String() string
+}
+
+type GoStringer interface {
+	GoString() string
+}
+`,
+	"io": `package io
+
+type Reader interface {
+	Read(p []byte) (n int, err error)
+}
+
+type Writer interface {
+	Write(p []byte) (n int, err error)
+}
+
+type Closer interface {
+	Close() error
+}
+
+type Seeker interface {
+	Seek(offset int64, whence int) (int64, error)
+}
+
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+type ReadCloser interface {
+	Reader
+	Closer
+}
+
+type WriteCloser interface {
+	Writer
+	Closer
+}
+
+type ReadWriteCloser interface {
+	Reader
+	Writer
+	Closer
+}
+
+type ReadSeeker interface {
+	Reader
+	Seeker
+}
+
+type ReaderAt interface {
+	ReadAt(p []byte, off int64) (n int, err error)
+}
+
+type WriterAt interface {
+	WriteAt(p []byte, off int64) (n int, err error)
+}
+
+type ReaderFrom interface {
+	ReadFrom(r Reader) (n int64, err error)
+}
+
+type WriterTo interface {
+	WriteTo(w Writer) (n int64, err error)
+}
+
+type ByteReader interface {
+	ReadByte() (byte, error)
+}
+
+type ByteWriter interface {
+	WriteByte(c byte) error
+}
+
+type StringWriter interface {
+	WriteString(s string) (n int, err error)
+}
+`,
+	"sort": `package sort
+
+type Interface interface {
+	Len() int
+	Less(i, j int) bool
+	Swap(i, j int)
+}
+`,
+	"context": `package context
+
+import "time"
+
+type Context interface {
+	Deadline() (deadline time.Time, ok bool)
+	Done() <-chan struct{}
+	Err() error
+	Value(key any) any
+}
+`,
+	"encoding": `package encoding
+
+type TextMarshaler interface {
+	MarshalText() (text []byte, err error)
+}
+
+type TextUnmarshaler interface {
+	UnmarshalText(text []byte) error
+}
+
+type BinaryMarshaler interface {
+	MarshalBinary() (data []byte, err error)
+}
+
+type BinaryUnmarshaler interface {
+	UnmarshalBinary(data []byte) error
+}
+`,
+	"encoding/json": `package json
+
+type Marshaler interface {
+	MarshalJSON() ([]byte, error)
+}
+
+type Unmarshaler interface {
+	UnmarshalJSON([]byte) error
+}
+`,
+	"sync": `package sync
+
+type Locker interface {
+	Lock()
+	Unlock()
+}
+`,
+}
+
+var (
+	wellKnownParser     *Parser
+	wellKnownParserOnce sync.Once
+)
+
+//wellKnownPackage returns the well known package with the import path, nil if the package is not well known
+func wellKnownPackage(importPath string) *parserPackage {
+	wellKnownParserOnce.Do(func() {
+		sources := map[string][]byte{}
+		for packagePath, source := range wellKnownSources {
+			sources[packagePath+"/"+"well_known.go"] = []byte(source)
+		}
+		parser, err := ParseSources(sources)
+		if err != nil {
+			// the sources are constant, they always parse
+			panic(err)
+		}
+
+		wellKnownParser = parser.(*Parser)
+		for _, pkg := range wellKnownParser.packages {
+			pkg.ImportPath = pkg.DirectoryPath
+		}
+	})
+
+	for _, pkg := range wellKnownParser.packages {
+		if pkg.ImportPath == importPath {
+			return pkg
+		}
+	}
+	return nil
+}